@@ -0,0 +1,170 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// BackpressurePolicy defines what an AsyncWriter does when its queue is full
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock waits until there is room in the queue
+	BackpressureBlock BackpressurePolicy = iota
+
+	// BackpressureDropNewest drops the message which is being written
+	BackpressureDropNewest
+
+	// BackpressureDropOldest drops the oldest message in the queue to make room for the new one
+	BackpressureDropOldest
+)
+
+// AsyncWriter is an io.Writer which queues the writes and performs them in the background
+//
+// It can be assigned to Stdout, Stderr or used as the writer of a sink. Use Flush to wait until all queued writes are
+// done and Close to stop the background goroutine and close the underlying writer. Fatal and CheckError flush the
+// outputs before exiting.
+type AsyncWriter struct {
+	writer    io.Writer
+	queueSize int
+	policy    BackpressurePolicy
+
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	queue   [][]byte
+	writing bool
+	closed  bool
+	dropped uint64
+	done    chan struct{}
+}
+
+// NewAsyncWriter returns a writer which writes to w in the background using a queue of queueSize messages
+func NewAsyncWriter(w io.Writer, queueSize int, policy BackpressurePolicy) *AsyncWriter {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	aw := &AsyncWriter{
+		writer:    w,
+		queueSize: queueSize,
+		policy:    policy,
+		done:      make(chan struct{}),
+	}
+	aw.cond = sync.NewCond(&aw.mutex)
+	go aw.run()
+	return aw
+}
+
+// Write queues p to be written in the background
+//
+// Once the writer is closed, the data is written synchronously after the queued messages.
+func (aw *AsyncWriter) Write(p []byte) (int, error) {
+
+	aw.mutex.Lock()
+
+	for !aw.closed && len(aw.queue) >= aw.queueSize {
+		switch aw.policy {
+		case BackpressureDropNewest:
+			aw.dropped++
+			aw.mutex.Unlock()
+			return len(p), nil
+		case BackpressureDropOldest:
+			aw.queue = aw.queue[1:]
+			aw.dropped++
+		default:
+			aw.cond.Wait()
+		}
+	}
+
+	// the writer might have been closed while waiting for room in the queue
+	if aw.closed {
+		aw.mutex.Unlock()
+		<-aw.done
+		aw.mutex.Lock()
+		defer aw.mutex.Unlock()
+		return aw.writer.Write(p)
+	}
+
+	aw.queue = append(aw.queue, append([]byte(nil), p...))
+	aw.cond.Broadcast()
+	aw.mutex.Unlock()
+
+	return len(p), nil
+
+}
+
+// Dropped returns the number of messages which were dropped because the queue was full
+func (aw *AsyncWriter) Dropped() uint64 {
+	aw.mutex.Lock()
+	defer aw.mutex.Unlock()
+	return aw.dropped
+}
+
+// Flush waits until all queued messages are written
+func (aw *AsyncWriter) Flush() error {
+	aw.mutex.Lock()
+	for len(aw.queue) > 0 || aw.writing {
+		aw.cond.Wait()
+	}
+	aw.mutex.Unlock()
+	return flushWriter(aw.writer)
+}
+
+// Close writes the queued messages, stops the background goroutine and closes the underlying writer
+//
+// Stdout and stderr are never closed. Closing the writer more than once has no effect.
+func (aw *AsyncWriter) Close() error {
+
+	aw.mutex.Lock()
+	if aw.closed {
+		aw.mutex.Unlock()
+		<-aw.done
+		return nil
+	}
+	aw.closed = true
+	aw.cond.Broadcast()
+	aw.mutex.Unlock()
+
+	<-aw.done
+
+	aw.mutex.Lock()
+	defer aw.mutex.Unlock()
+
+	result := flushWriter(aw.writer)
+	if err := closeWriter(aw.writer); err != nil && result == nil {
+		result = err
+	}
+	return result
+
+}
+
+func (aw *AsyncWriter) run() {
+
+	aw.mutex.Lock()
+	defer aw.mutex.Unlock()
+
+	for {
+
+		for len(aw.queue) == 0 && !aw.closed {
+			aw.cond.Wait()
+		}
+
+		if len(aw.queue) == 0 {
+			close(aw.done)
+			return
+		}
+
+		data := aw.queue[0]
+		aw.queue = aw.queue[1:]
+		aw.writing = true
+		aw.cond.Broadcast()
+
+		aw.mutex.Unlock()
+		aw.writer.Write(data)
+		aw.mutex.Lock()
+
+		aw.writing = false
+		aw.cond.Broadcast()
+
+	}
+
+}
@@ -0,0 +1,322 @@
+package log_test
+
+import (
+	"bytes"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingWriter blocks each write until release is called
+type blockingWriter struct {
+	mutex   sync.Mutex
+	buf     bytes.Buffer
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingWriter() *blockingWriter {
+	return &blockingWriter{
+		started: make(chan struct{}, 100),
+		release: make(chan struct{}),
+	}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.started <- struct{}{}
+	<-w.release
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.buf.String()
+}
+
+// closingWriter records the writes and how many times it was closed
+type closingWriter struct {
+	buf    bytes.Buffer
+	closed int
+}
+
+func (w *closingWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *closingWriter) Close() error {
+	w.closed++
+	return nil
+}
+
+// lockedBuffer is a buffer which is safe for concurrent use and takes delay for each write
+type lockedBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+	delay time.Duration
+}
+
+func (w *lockedBuffer) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *lockedBuffer) String() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.buf.String()
+}
+
+func TestAsyncWriter(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	aw := log.NewAsyncWriter(&buf, 10, log.BackpressureBlock)
+
+	logger, _, _ := newTestLogger()
+	logger.Stdout = aw
+
+	for i := 0; i < 100; i++ {
+		logger.Infof("info %d", i)
+	}
+
+	assert.NoError(t, logger.Flush())
+	assert.Equal(t, 100, bytes.Count(buf.Bytes(), []byte("\n")))
+	assert.Equal(t, uint64(0), aw.Dropped())
+
+	assert.NoError(t, aw.Close())
+
+}
+
+func TestAsyncWriterDropNewest(t *testing.T) {
+
+	w := newBlockingWriter()
+	aw := log.NewAsyncWriter(w, 2, log.BackpressureDropNewest)
+
+	write(t, aw, "1\n")
+	<-w.started
+
+	write(t, aw, "2\n")
+	write(t, aw, "3\n")
+	write(t, aw, "4\n")
+	write(t, aw, "5\n")
+
+	close(w.release)
+	assert.NoError(t, aw.Close())
+
+	assert.Equal(t, "1\n2\n3\n", w.String())
+	assert.Equal(t, uint64(2), aw.Dropped())
+
+}
+
+func TestAsyncWriterDropOldest(t *testing.T) {
+
+	w := newBlockingWriter()
+	aw := log.NewAsyncWriter(w, 2, log.BackpressureDropOldest)
+
+	write(t, aw, "1\n")
+	<-w.started
+
+	write(t, aw, "2\n")
+	write(t, aw, "3\n")
+	write(t, aw, "4\n")
+	write(t, aw, "5\n")
+
+	close(w.release)
+	assert.NoError(t, aw.Close())
+
+	assert.Equal(t, "1\n4\n5\n", w.String())
+	assert.Equal(t, uint64(2), aw.Dropped())
+
+}
+
+func TestAsyncWriterBlock(t *testing.T) {
+
+	w := newBlockingWriter()
+	aw := log.NewAsyncWriter(w, 1, log.BackpressureBlock)
+
+	write(t, aw, "1\n")
+	<-w.started
+	write(t, aw, "2\n")
+
+	written := make(chan struct{})
+	go func() {
+		write(t, aw, "3\n")
+		close(written)
+	}()
+
+	select {
+	case <-written:
+		t.Fatal("write should block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(w.release)
+	<-written
+	assert.NoError(t, aw.Close())
+
+	assert.Equal(t, "1\n2\n3\n", w.String())
+	assert.Equal(t, uint64(0), aw.Dropped())
+
+}
+
+func TestAsyncWriterWriteAfterClose(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	aw := log.NewAsyncWriter(&buf, 1, log.BackpressureBlock)
+	assert.NoError(t, aw.Close())
+
+	write(t, aw, "after\n")
+	assert.Equal(t, "after\n", buf.String())
+
+}
+
+func TestAsyncWriterCloseClosesWriter(t *testing.T) {
+
+	w := &closingWriter{}
+
+	aw := log.NewAsyncWriter(w, 10, log.BackpressureBlock)
+	write(t, aw, "queued\n")
+
+	assert.NoError(t, aw.Close())
+	assert.NoError(t, aw.Close())
+
+	assert.Equal(t, "queued\n", w.buf.String())
+	assert.Equal(t, 1, w.closed)
+
+}
+
+func TestAsyncWriterWriteDuringClose(t *testing.T) {
+
+	w := newBlockingWriter()
+
+	aw := log.NewAsyncWriter(w, 10, log.BackpressureBlock)
+	write(t, aw, "1\n")
+	<-w.started
+
+	closed := make(chan error)
+	go func() {
+		closed <- aw.Close()
+	}()
+
+	// give Close the time to mark the writer as closed
+	time.Sleep(50 * time.Millisecond)
+
+	written := make(chan struct{})
+	go func() {
+		aw.Write([]byte("2\n"))
+		close(written)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, len(w.started), "write after close didn't wait for the queued messages")
+
+	close(w.release)
+	<-written
+	assert.NoError(t, <-closed)
+	assert.Equal(t, "1\n2\n", w.String())
+
+}
+
+func TestAsyncWriterWriteRacingClose(t *testing.T) {
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for i := 0; i < 20000; i++ {
+
+		w := &lockedBuffer{}
+		aw := log.NewAsyncWriter(w, 1, log.BackpressureBlock)
+
+		var wg sync.WaitGroup
+		for j := 0; j < 4; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				aw.Write([]byte("x\n"))
+			}()
+		}
+
+		finished := make(chan struct{})
+		go func() {
+			aw.Close()
+			wg.Wait()
+			aw.Flush()
+			close(finished)
+		}()
+
+		select {
+		case <-finished:
+		case <-time.After(5 * time.Second):
+			t.Fatal("write racing close didn't return")
+		}
+
+		assert.Equal(t, "x\nx\nx\nx\n", w.String())
+
+	}
+
+}
+
+func TestFatalFlushesAsyncWriter(t *testing.T) {
+
+	resetLogConfig()
+	redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+
+	oldOsExit := log.OsExit
+	defer func() {
+		log.OsExit = oldOsExit
+	}()
+
+	var buf bytes.Buffer
+	aw := log.NewAsyncWriter(&buf, 100, log.BackpressureBlock)
+	defer aw.Close()
+
+	var atExit string
+	log.OsExit = func(code int) {
+		atExit = buf.String()
+	}
+
+	log.Stderr = aw
+	log.Error("error")
+	log.CheckError(assert.AnError)
+
+	assert.Equal(t, "test | ERROR | error\ntest | FATAL | "+assert.AnError.Error()+"\n", atExit)
+
+}
+
+func TestClose(t *testing.T) {
+
+	resetLogConfig()
+	redirectOutput()
+	defer resetLogOutput()
+	defer log.ResetSinks()
+
+	var stdout, sinkBuf bytes.Buffer
+	aw := log.NewAsyncWriter(&stdout, 100, log.BackpressureBlock)
+	sinkWriter := log.NewAsyncWriter(&sinkBuf, 100, log.BackpressureBlock)
+
+	log.PrintColors = false
+	log.Stdout = aw
+	log.Stderr = aw
+
+	log.Info("info")
+	assert.NoError(t, log.Close())
+	assert.Equal(t, "test | INFO  | info\n", stdout.String())
+
+	log.AddSink(log.NewWriterSink(sinkWriter, nil, log.TraceLevel))
+	log.Info("sink")
+	assert.NoError(t, log.Close())
+	assert.Equal(t, "test | INFO  | sink\n", sinkBuf.String())
+
+}
@@ -0,0 +1,99 @@
+package log
+
+import (
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// packagePrefix is the prefix of the functions in this package which are skipped when looking for the caller
+const packagePrefix = "github.com/pieterclaerhout/go-log."
+
+// WithCallerSkip returns a logger based on the default logger which skips extra stack frames when determining the
+// caller
+//
+// Use this when wrapping the log functions in your own helper functions so that the caller points to the code calling
+// the helper instead of the helper itself.
+func WithCallerSkip(skip int) *Logger {
+	return defaultLogger().WithCallerSkip(skip)
+}
+
+// WithCallerSkip returns a copy of the logger which skips extra stack frames when determining the caller
+//
+// The skip is added to the skip of the logger itself. Frames from this package are always skipped.
+func (l *Logger) WithCallerSkip(skip int) *Logger {
+	clone := *l
+	clone.callerSkip += skip
+	return &clone
+}
+
+func (l *Logger) caller() *runtime.Frame {
+	if !l.PrintCaller {
+		return nil
+	}
+	return l.callerFrame()
+}
+
+// callerFrame returns the first frame outside this package, runtime frames are skipped so that the caller of a
+// recovered panic is the code which panicked
+func (l *Logger) callerFrame() *runtime.Frame {
+
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	skip := l.callerSkip
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !strings.HasPrefix(frame.Function, packagePrefix) && !strings.HasPrefix(frame.Function, "runtime.") {
+			if skip <= 0 {
+				return &frame
+			}
+			skip--
+		}
+		if !more {
+			return nil
+		}
+	}
+
+}
+
+func (l *Logger) callerPC() uintptr {
+	frame := l.callerFrame()
+	if frame == nil {
+		return 0
+	}
+	return frame.PC + 1
+}
+
+func frameFromPC(pc uintptr) *runtime.Frame {
+	if pc == 0 {
+		return nil
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if frame.Function == "" && frame.File == "" {
+		return nil
+	}
+	return &frame
+}
+
+func (l *Logger) formatCaller(caller *runtime.Frame) string {
+	result := formatCallerFile(caller)
+	if l.PrintCallerFunction {
+		result += " " + formatCallerFunction(caller)
+	}
+	return result
+}
+
+func formatCallerFile(caller *runtime.Frame) string {
+	dir, file := path.Split(caller.File)
+	if dir != "" {
+		file = path.Base(dir) + "/" + file
+	}
+	return file + ":" + strconv.Itoa(caller.Line)
+}
+
+func formatCallerFunction(caller *runtime.Frame) string {
+	return path.Base(caller.Function)
+}
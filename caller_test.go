@@ -0,0 +1,124 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+	"runtime"
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintCaller(t *testing.T) {
+
+	resetLogConfig()
+	stdout, _ := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+	log.PrintCaller = true
+	log.DebugMode = true
+
+	log.Debugf("debug %d", 1)
+	line := currentLine() - 1
+
+	assert.Equal(t, fmt.Sprintf("test | DEBUG | %s:%d | debug 1\n", currentFile(), line), stdout.String())
+
+}
+
+func TestPrintCallerFunction(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.PrintTimestamp = false
+	logger.PrintCaller = true
+	logger.PrintCallerFunction = true
+
+	logger.InfoDump("value", "")
+	line := currentLine() - 1
+
+	assert.Equal(t, fmt.Sprintf("%s:%d go-log_test.TestPrintCallerFunction | \"value\"\n", currentFile(), line), stdout.String())
+
+}
+
+func TestPrintCallerWithCallerSkip(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.PrintTimestamp = false
+	logger.PrintCaller = true
+
+	logWrapper(logger, "wrapped")
+	line := currentLine() - 1
+
+	assert.Equal(t, fmt.Sprintf("%s:%d | wrapped\n", currentFile(), line), stdout.String())
+
+}
+
+func TestPrintCallerJSON(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.PrintCaller = true
+	logger.PrintCallerFunction = true
+	logger.OutputFormat = log.FormatJSON
+
+	logger.Warn("warn")
+	line := currentLine() - 1
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &record))
+	assert.Equal(t, fmt.Sprintf("%s:%d", currentFile(), line), record["caller"])
+	assert.Equal(t, "go-log_test.TestPrintCallerJSON", record["func"])
+
+}
+
+func TestPrintCallerSlogHandler(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.PrintTimestamp = false
+	logger.PrintCaller = true
+
+	slog.New(log.NewSlogHandler(logger)).Info("info")
+	line := currentLine() - 1
+
+	assert.Equal(t, fmt.Sprintf("%s:%d | info\n", currentFile(), line), stdout.String())
+
+}
+
+func TestPrintCallerSlogBackend(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	logger, _, _ := newTestLogger()
+	logger.SlogBackend = slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true})
+
+	logger.Infof("info %d", 1)
+	line := currentLine() - 1
+
+	var record struct {
+		Source struct {
+			Function string `json:"function"`
+			Line     int    `json:"line"`
+		} `json:"source"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, line, record.Source.Line)
+	assert.Equal(t, "github.com/pieterclaerhout/go-log_test.TestPrintCallerSlogBackend", record.Source.Function)
+
+}
+
+func logWrapper(logger *log.Logger, message string) {
+	logger.WithCallerSkip(1).Info(message)
+}
+
+func currentFile() string {
+	_, file, _, _ := runtime.Caller(1)
+	return path.Base(path.Dir(file)) + "/" + path.Base(file)
+}
+
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
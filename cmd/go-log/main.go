@@ -8,12 +8,18 @@ import (
 func main() {
 
 	log.DebugMode = true
+	log.TraceMode = true
 	log.PrintTimestamp = true
 	log.PrintColors = true
 	log.TimeFormat = "2006-01-02 15:04:05.000"
 
 	myVar := map[string]string{"hello": "world"}
 
+	log.Trace("trace arg1", "trace arg2")
+	log.Tracef("trace arg1 %d", 1)
+	log.TraceDump(myVar, "trace prefix")
+	log.TraceSeparator("trace title")
+
 	log.Debug("debug arg1", "debug arg2")
 	log.Debugf("debug arg1 %d", 1)
 	log.DebugDump(myVar, "debug prefix")
@@ -0,0 +1,95 @@
+package log
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx which carries the fields
+//
+// The arguments can be Field values or alternating key/value pairs such as WithContext(ctx, "request_id", id). Fields
+// which are already stored in ctx are kept. The fields are added to each message logged with one of the Context
+// functions or with a logger returned by FromContext.
+func WithContext(ctx context.Context, args ...interface{}) context.Context {
+	existing := FieldsFromContext(ctx)
+	fields := append(existing[:len(existing):len(existing)], argsToFields(args)...)
+	return context.WithValue(ctx, contextKey{}, fields)
+}
+
+// FieldsFromContext returns the fields which are stored in ctx using WithContext
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(contextKey{}).([]Field)
+	return fields
+}
+
+// FromContext returns a logger based on the default logger which adds the fields stored in ctx to each message
+func FromContext(ctx context.Context) *Logger {
+	return defaultLogger().FromContext(ctx)
+}
+
+// FromContext returns a copy of the logger which adds the fields stored in ctx to each message
+func (l *Logger) FromContext(ctx context.Context) *Logger {
+	return l.withFields(FieldsFromContext(ctx))
+}
+
+// TraceContext prints a trace message with the fields stored in ctx
+//
+// Only shown if TraceMode is set to true
+func TraceContext(ctx context.Context, args ...interface{}) {
+	FromContext(ctx).Trace(args...)
+}
+
+// DebugContext prints a debug message with the fields stored in ctx
+//
+// Only shown if DebugMode is set to true
+func DebugContext(ctx context.Context, args ...interface{}) {
+	FromContext(ctx).Debug(args...)
+}
+
+// InfoContext prints an info message with the fields stored in ctx
+func InfoContext(ctx context.Context, args ...interface{}) {
+	FromContext(ctx).Info(args...)
+}
+
+// WarnContext prints a warning message with the fields stored in ctx
+func WarnContext(ctx context.Context, args ...interface{}) {
+	FromContext(ctx).Warn(args...)
+}
+
+// ErrorContext prints an error message with the fields stored in ctx to stderr
+func ErrorContext(ctx context.Context, args ...interface{}) {
+	FromContext(ctx).Error(args...)
+}
+
+// TraceContext prints a trace message with the fields stored in ctx
+//
+// Only shown if TraceMode is set to true
+func (l *Logger) TraceContext(ctx context.Context, args ...interface{}) {
+	l.FromContext(ctx).Trace(args...)
+}
+
+// DebugContext prints a debug message with the fields stored in ctx
+//
+// Only shown if DebugMode is set to true
+func (l *Logger) DebugContext(ctx context.Context, args ...interface{}) {
+	l.FromContext(ctx).Debug(args...)
+}
+
+// InfoContext prints an info message with the fields stored in ctx
+func (l *Logger) InfoContext(ctx context.Context, args ...interface{}) {
+	l.FromContext(ctx).Info(args...)
+}
+
+// WarnContext prints a warning message with the fields stored in ctx
+func (l *Logger) WarnContext(ctx context.Context, args ...interface{}) {
+	l.FromContext(ctx).Warn(args...)
+}
+
+// ErrorContext prints an error message with the fields stored in ctx to stderr
+func (l *Logger) ErrorContext(ctx context.Context, args ...interface{}) {
+	l.FromContext(ctx).Error(args...)
+}
@@ -0,0 +1,83 @@
+package log_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextFunctions(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+	log.DebugMode = true
+
+	ctx := log.WithContext(context.Background(), "request_id", "abc")
+	ctx = log.WithContext(ctx, log.String("tenant", "acme"))
+
+	log.DebugContext(ctx, "debug")
+	log.InfoContext(ctx, "info")
+	log.WarnContext(ctx, "warn")
+	log.ErrorContext(ctx, "error")
+	log.FromContext(ctx).With("user", 1).Infof("info %d", 2)
+
+	expectedStdout := "test | DEBUG | debug request_id=abc tenant=acme\n" +
+		"test | INFO  | info request_id=abc tenant=acme\n" +
+		"test | WARN  | warn request_id=abc tenant=acme\n" +
+		"test | INFO  | info 2 request_id=abc tenant=acme user=1\n"
+
+	assert.Equal(t, expectedStdout, stdout.String())
+	assert.Equal(t, "test | ERROR | error request_id=abc tenant=acme\n", stderr.String())
+
+}
+
+func TestContextDoesNotLeak(t *testing.T) {
+
+	parent := log.WithContext(context.Background(), "a", 1)
+	child1 := log.WithContext(parent, "b", 2)
+	child2 := log.WithContext(parent, "c", 3)
+
+	assert.Equal(t, []log.Field{{Key: "a", Value: 1}}, log.FieldsFromContext(parent))
+	assert.Equal(t, []log.Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}}, log.FieldsFromContext(child1))
+	assert.Equal(t, []log.Field{{Key: "a", Value: 1}, {Key: "c", Value: 3}}, log.FieldsFromContext(child2))
+	assert.Nil(t, log.FieldsFromContext(context.Background()))
+
+}
+
+func TestLoggerContext(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+	logger.DebugMode = true
+
+	ctx := log.WithContext(context.Background(), "request_id", "abc")
+
+	logger.With("component", "api").InfoContext(ctx, "info")
+	logger.DebugContext(ctx, "debug")
+	logger.WarnContext(context.Background(), "warn")
+	logger.ErrorContext(ctx, "error")
+
+	expectedStdout := "test | INFO  | info component=api request_id=abc\n" +
+		"test | DEBUG | debug request_id=abc\n" +
+		"test | WARN  | warn\n"
+
+	assert.Equal(t, expectedStdout, stdout.String())
+	assert.Equal(t, "test | ERROR | error request_id=abc\n", stderr.String())
+
+}
+
+func TestSlogHandlerContext(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+
+	ctx := log.WithContext(context.Background(), "request_id", "abc")
+	slog.New(log.NewSlogHandler(logger)).InfoContext(ctx, "info", "user", 1)
+
+	assert.Equal(t, "test | INFO  | info request_id=abc user=1\n", stdout.String())
+
+}
@@ -0,0 +1,75 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+)
+
+// duplicateTracker remembers the last printed entry to collapse consecutive duplicates
+type duplicateTracker struct {
+	mutex sync.Mutex
+	last  *Entry
+	count int
+}
+
+var duplicates = &duplicateTracker{}
+
+// print prints the entry unless it's a duplicate of the previous one
+//
+// When a different entry arrives, the number of suppressed duplicates of the previous one is printed first.
+func (d *duplicateTracker) print(entry *Entry) {
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.last != nil && isDuplicateEntry(d.last, entry) {
+		d.count++
+		return
+	}
+
+	d.printRepeated()
+	d.last = entry
+	entry.Logger.writeEntry(entry)
+
+}
+
+// flush prints the number of suppressed duplicates of the last entry
+func (d *duplicateTracker) flush() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.printRepeated()
+}
+
+func (d *duplicateTracker) printRepeated() {
+	if d.last != nil && d.count > 0 {
+		summary := *d.last
+		summary.Time = d.last.Logger.now()
+		d.last.Logger.stamp(&summary)
+		summary.Message = formatRepeated(d.count)
+		summary.Fields = nil
+		summary.Error = nil
+		summary.Panic = nil
+		summary.Stack = ""
+		d.last.Logger.writeEntry(&summary)
+	}
+	d.last = nil
+	d.count = 0
+}
+
+func (l *Logger) flushDuplicates() {
+	if l.duplicates == nil {
+		return
+	}
+	l.duplicates.flush()
+}
+
+func isDuplicateEntry(a *Entry, b *Entry) bool {
+	return a.Level == b.Level && a.Message == b.Message && formatFields(a.Fields) == formatFields(b.Fields)
+}
+
+func formatRepeated(count int) string {
+	if count == 1 {
+		return "(repeated 1 time)"
+	}
+	return fmt.Sprintf("(repeated %d times)", count)
+}
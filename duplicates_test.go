@@ -0,0 +1,106 @@
+package log_test
+
+import (
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollapseDuplicates(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+	logger.CollapseDuplicates = true
+
+	for i := 0; i < 50; i++ {
+		logger.Info("hello")
+	}
+	logger.Warn("hello")
+	logger.Warn("hello")
+	logger.Error("failed")
+
+	assert.Equal(t, "test | INFO  | hello\ntest | INFO  | (repeated 49 times)\ntest | WARN  | hello\ntest | WARN  | (repeated 1 time)\n", stdout.String())
+	assert.Equal(t, "test | ERROR | failed\n", stderr.String())
+
+}
+
+func TestCollapseDuplicatesColors(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.CollapseDuplicates = true
+	logger.PrintColors = true
+
+	logger.Info("hello")
+	logger.Info("hello")
+	logger.Info("hello")
+
+	assert.Equal(t, "\x1b[92mtest | INFO  | hello\x1b[0m\n", stdout.String())
+
+	assert.NoError(t, logger.Flush())
+	assert.Equal(t, "\x1b[92mtest | INFO  | hello\x1b[0m\n\x1b[92mtest | INFO  | (repeated 2 times)\x1b[0m\n", stdout.String())
+
+}
+
+func TestCollapseDuplicatesFlush(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.CollapseDuplicates = true
+	logger.PrintTimestamp = false
+
+	logger.Info("hello")
+	logger.Info("hello")
+	assert.NoError(t, logger.Flush())
+	logger.Info("hello")
+	assert.NoError(t, logger.Flush())
+
+	assert.Equal(t, "hello\n(repeated 1 time)\nhello\n", stdout.String())
+
+}
+
+func TestCollapseDuplicatesFields(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.CollapseDuplicates = true
+	logger.PrintTimestamp = false
+
+	logger.With("id", 1).Info("hello")
+	logger.With("id", 1).Info("hello")
+	logger.With("id", 2).Info("hello")
+
+	assert.Equal(t, "hello id=1\n(repeated 1 time)\nhello id=2\n", stdout.String())
+
+}
+
+func TestCollapseDuplicatesDisabled(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.PrintTimestamp = false
+
+	logger.Info("hello")
+	logger.Info("hello")
+
+	assert.Equal(t, "hello\nhello\n", stdout.String())
+
+}
+
+func TestCollapseDuplicatesPackage(t *testing.T) {
+
+	resetLogConfig()
+	stdout, _ := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintTimestamp = false
+	log.PrintColors = false
+	log.CollapseDuplicates = true
+	defer func() {
+		log.Flush()
+		log.CollapseDuplicates = false
+	}()
+
+	log.Info("hello")
+	log.Info("hello")
+	log.Info("world")
+
+	assert.Equal(t, "hello\n(repeated 1 time)\nworld\n", stdout.String())
+
+}
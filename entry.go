@@ -0,0 +1,62 @@
+package log
+
+import (
+	"runtime"
+	"time"
+)
+
+// Entry contains all information about a single log message which is passed to a Formatter
+type Entry struct {
+
+	// Logger is the logger which created the entry, formatters use it for settings such as TimeZone and TimeFormat
+	Logger *Logger
+
+	// Time is the moment the message was logged (can be zero if it is unknown)
+	Time time.Time
+
+	// Level is the level of the message
+	Level Level
+
+	// Message is the formatted message
+	Message string
+
+	// Fields are the key/value pairs which are attached to the message
+	Fields []Field
+
+	// Caller is the location from which the message was logged (nil unless PrintCaller is enabled)
+	Caller *runtime.Frame
+
+	// Error is the error the message is about, set when logging a stack trace or a recovered panic
+	Error error
+
+	// Panic is the value which was recovered, set when logging a recovered panic
+	Panic interface{}
+
+	// Stack is the formatted stack trace, set when logging a stack trace or a recovered panic
+	Stack string
+
+	previous time.Time
+}
+
+// FormattedTime returns the time of the entry in the time zone and format of the logger (DefaultTimeFormat if empty)
+//
+// When the TimestampMode of the logger is TimeElapsed or TimeDelta, the relative time is returned instead.
+func (e *Entry) FormattedTime() string {
+	switch e.Logger.TimestampMode {
+	case TimeElapsed:
+		return formatElapsed(e.Time.Sub(e.Logger.startTime()))
+	case TimeDelta:
+		if e.previous.IsZero() {
+			return formatDelta(0)
+		}
+		return formatDelta(e.Time.Sub(e.previous))
+	}
+	tstamp := e.Time
+	if e.Logger.TimeZone != nil {
+		tstamp = tstamp.In(e.Logger.TimeZone)
+	}
+	if e.Logger.TimeFormat == "" {
+		return tstamp.Format(DefaultTimeFormat)
+	}
+	return tstamp.Format(e.Logger.TimeFormat)
+}
@@ -0,0 +1,74 @@
+package log
+
+import (
+	"errors"
+	"sync"
+)
+
+// ExitCoder is implemented by errors which define the exit code used by CheckError
+type ExitCoder interface {
+	ExitCode() int
+}
+
+var (
+	exitHandlers      []func()
+	exitHandlersMutex sync.Mutex
+)
+
+// OnExit registers a function which is called before the program exits because of Fatal or CheckError
+//
+// The functions are called in the reverse order in which they were registered, before the outputs are flushed. Use it
+// to close database connections or to remove temporary files. Each function is called only once.
+func OnExit(fn func()) {
+	exitHandlersMutex.Lock()
+	defer exitHandlersMutex.Unlock()
+	exitHandlers = append(exitHandlers, fn)
+}
+
+// WithExitCode returns an error which wraps err and makes CheckError exit with the given code
+func WithExitCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{err: err, code: code}
+}
+
+type exitCodeError struct {
+	err  error
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+func (e *exitCodeError) ExitCode() int {
+	return e.code
+}
+
+// exitCode returns the exit code of the first error in the chain which implements ExitCoder, 1 otherwise
+func exitCode(err error) int {
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return 1
+}
+
+// runExitHandlers calls the registered exit handlers in LIFO order and removes them
+func runExitHandlers() {
+
+	exitHandlersMutex.Lock()
+	handlers := exitHandlers
+	exitHandlers = nil
+	exitHandlersMutex.Unlock()
+
+	for i := len(handlers) - 1; i >= 0; i-- {
+		handlers[i]()
+	}
+
+}
@@ -0,0 +1,157 @@
+package log_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type notFoundError struct {
+	name string
+}
+
+func (e *notFoundError) Error() string {
+	return e.name + " not found"
+}
+
+func (e *notFoundError) ExitCode() int {
+	return 3
+}
+
+func TestCheckErrorExitCoder(t *testing.T) {
+
+	type test struct {
+		name     string
+		err      error
+		expected int
+	}
+
+	var tests = []test{
+		{"plain", errors.New("failed"), 1},
+		{"exit-coder", &notFoundError{name: "config"}, 3},
+		{"wrapped", fmt.Errorf("loading: %w", &notFoundError{name: "config"}), 3},
+		{"with-exit-code", log.WithExitCode(errors.New("failed"), 4), 4},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			logger, _, _ := newTestLogger()
+
+			got := -1
+			logger.OsExit = func(code int) {
+				got = code
+			}
+
+			logger.CheckError(tc.err)
+
+			assert.Equal(t, tc.expected, got)
+
+		})
+	}
+
+}
+
+func TestWithExitCode(t *testing.T) {
+
+	err := errors.New("failed")
+
+	assert.Nil(t, log.WithExitCode(nil, 2))
+	assert.Equal(t, "failed", log.WithExitCode(err, 2).Error())
+	assert.True(t, errors.Is(log.WithExitCode(err, 2), err))
+
+}
+
+func TestCheckErrorf(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+
+	got := -1
+	logger.OsExit = func(code int) {
+		got = code
+	}
+
+	logger.CheckErrorf(nil, "while loading %s", "config.yaml")
+	assert.Equal(t, -1, got)
+
+	logger.CheckErrorf(&notFoundError{name: "config.yaml"}, "while loading %s", "config.yaml")
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "test | FATAL | while loading config.yaml: config.yaml not found\n", stderr.String())
+	assert.Equal(t, 3, got)
+
+}
+
+func TestPackageCheckErrorf(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+
+	oldOsExit := log.OsExit
+	defer func() {
+		log.OsExit = oldOsExit
+	}()
+
+	var got int
+	log.OsExit = func(code int) {
+		got = code
+	}
+
+	log.CheckErrorf(errors.New("permission denied"), "while loading %s", "config.yaml")
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "test | FATAL | while loading config.yaml: permission denied\n", stderr.String())
+	assert.Equal(t, 1, got)
+
+}
+
+func TestOnExit(t *testing.T) {
+
+	logger, _, stderr := newTestLogger()
+
+	var calls []string
+	logger.OsExit = func(code int) {
+		calls = append(calls, fmt.Sprintf("exit %d", code))
+	}
+
+	log.OnExit(func() { calls = append(calls, "close db") })
+	log.OnExit(func() {
+		calls = append(calls, "remove temp files")
+		logger.Info("cleaning up")
+	})
+
+	logger.Fatal("fatal")
+
+	assert.Equal(t, []string{"remove temp files", "close db", "exit 1"}, calls)
+	assert.Equal(t, "test | FATAL | fatal\n", stderr.String())
+
+	calls = nil
+	logger.CheckError(errors.New("failed"))
+
+	assert.Equal(t, []string{"exit 1"}, calls)
+
+}
+
+func TestOnExitCheckError(t *testing.T) {
+
+	logger, _, _ := newTestLogger()
+
+	var calls []string
+	logger.OsExit = func(code int) {
+		calls = append(calls, fmt.Sprintf("exit %d", code))
+	}
+
+	log.OnExit(func() { calls = append(calls, "first") })
+	log.OnExit(func() { calls = append(calls, "second") })
+
+	logger.CheckError(log.WithExitCode(errors.New("failed"), 2))
+
+	assert.Equal(t, []string{"second", "first", "exit 2"}, calls)
+
+}
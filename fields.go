@@ -0,0 +1,136 @@
+package log
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// badKey is the key used for a value in With which doesn't have a matching key
+const badKey = "!BADKEY"
+
+// Field is a key/value pair which is attached to a log message
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a field with a string value
+func String(key string, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int returns a field with an int value
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int64 returns a field with an int64 value
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Float64 returns a field with a float64 value
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool returns a field with a bool value
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration returns a field with a time.Duration value
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Time returns a field with a time.Time value
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err returns a field with the key "error" containing err
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+// Any returns a field with an arbitrary value
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// With returns a logger based on the default logger which adds the fields to each message
+//
+// The arguments can be Field values or alternating key/value pairs such as With("user_id", 42).
+func With(args ...interface{}) *Logger {
+	return defaultLogger().With(args...)
+}
+
+// With returns a copy of the logger which adds the fields to each message
+//
+// The arguments can be Field values or alternating key/value pairs such as With("user_id", 42).
+func (l *Logger) With(args ...interface{}) *Logger {
+	return l.withFields(argsToFields(args))
+}
+
+func (l *Logger) withFields(fields []Field) *Logger {
+	clone := *l
+	clone.fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+	return &clone
+}
+
+func argsToFields(args []interface{}) []Field {
+	fields := make([]Field, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i].(type) {
+		case Field:
+			fields = append(fields, arg)
+		case []Field:
+			fields = append(fields, arg...)
+		case string:
+			if i+1 >= len(args) {
+				fields = append(fields, Field{Key: badKey, Value: arg})
+				continue
+			}
+			fields = append(fields, Field{Key: arg, Value: args[i+1]})
+			i++
+		default:
+			fields = append(fields, Field{Key: badKey, Value: arg})
+		}
+	}
+	return fields
+}
+
+func formatFields(fields []Field) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		parts = append(parts, field.Key+"="+quoteFieldValue(formatFieldValue(field.Value)))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "<nil>"
+	case string:
+		return v
+	case error:
+		return v.Error()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func quoteFieldValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " =\"\t\r\n") {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,84 @@
+package log_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWith(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+
+	log.With("user_id", 42).Info("login")
+	log.With(log.String("user", "john doe"), log.Bool("admin", true)).Error("denied")
+
+	assert.Equal(t, "test | INFO  | login user_id=42\n", stdout.String())
+	assert.Equal(t, "test | ERROR | denied user=\"john doe\" admin=true\n", stderr.String())
+
+}
+
+func TestWithChained(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+
+	requestLogger := logger.With("request_id", "abc")
+	requestLogger.With("step", 1).Info("first")
+	requestLogger.With("step", 2).Info("second")
+	logger.Info("plain")
+
+	expected := "test | INFO  | first request_id=abc step=1\n" +
+		"test | INFO  | second request_id=abc step=2\n" +
+		"test | INFO  | plain\n"
+
+	assert.Equal(t, expected, stdout.String())
+
+}
+
+func TestFieldFormatting(t *testing.T) {
+
+	type test struct {
+		name     string
+		args     []interface{}
+		expected string
+	}
+
+	var tests = []test{
+		{"string", []interface{}{log.String("key", "value")}, "key=value"},
+		{"string-empty", []interface{}{log.String("key", "")}, "key=\"\""},
+		{"string-quoted", []interface{}{log.String("key", "a \"b\"")}, "key=\"a \\\"b\\\"\""},
+		{"int", []interface{}{log.Int("key", 1)}, "key=1"},
+		{"int64", []interface{}{log.Int64("key", 2)}, "key=2"},
+		{"float64", []interface{}{log.Float64("key", 1.5)}, "key=1.5"},
+		{"bool", []interface{}{log.Bool("key", false)}, "key=false"},
+		{"duration", []interface{}{log.Duration("key", 2*time.Second)}, "key=2s"},
+		{"time", []interface{}{log.Time("key", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))}, "key=2020-01-02T03:04:05Z"},
+		{"err", []interface{}{log.Err(errors.New("my error"))}, "error=\"my error\""},
+		{"any", []interface{}{log.Any("key", []int{1, 2})}, "key=\"[1 2]\""},
+		{"nil", []interface{}{log.Any("key", nil)}, "key=<nil>"},
+		{"pairs", []interface{}{"a", 1, "b", "two"}, "a=1 b=two"},
+		{"missing-value", []interface{}{"a"}, "!BADKEY=a"},
+		{"bad-key", []interface{}{1, "a", 2}, "!BADKEY=1 a=2"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			logger, stdout, _ := newTestLogger()
+			logger.PrintTimestamp = false
+
+			logger.With(tc.args...).Info("msg")
+
+			assert.Equal(t, "msg "+tc.expected+"\n", stdout.String())
+
+		})
+	}
+
+}
@@ -0,0 +1,102 @@
+package log
+
+import (
+	"io"
+	"os"
+	"reflect"
+)
+
+// flusher is implemented by outputs which buffer their data, such as AsyncWriter
+type flusher interface {
+	Flush() error
+}
+
+// Flush waits until all buffered messages of the package-level functions are written
+func Flush() error {
+	return defaultLogger().Flush()
+}
+
+// Close flushes the outputs of the package-level functions and closes them
+//
+// os.Stdout and os.Stderr are never closed.
+func Close() error {
+	return defaultLogger().Close()
+}
+
+// Flush waits until all buffered messages are written
+//
+// It prints the summaries of the suppressed and collapsed messages and flushes Stdout, Stderr and the sinks if they
+// support it. Fatal and CheckError call Flush before exiting.
+func (l *Logger) Flush() error {
+	l.flushSampler()
+	l.flushDuplicates()
+	var result error
+	for _, output := range l.outputs() {
+		if err := flushWriter(output); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+// Close flushes the outputs and closes them
+//
+// os.Stdout and os.Stderr are never closed.
+func (l *Logger) Close() error {
+	result := l.Flush()
+	for _, output := range l.outputs() {
+		if err := closeWriter(output); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+func (l *Logger) outputs() []interface{} {
+	var outputs []interface{}
+	for _, output := range []interface{}{l.Stdout, l.Stderr} {
+		if output != nil && !containsOutput(outputs, output) {
+			outputs = append(outputs, output)
+		}
+	}
+	for _, w := range l.routedWriters() {
+		if !containsOutput(outputs, w) {
+			outputs = append(outputs, w)
+		}
+	}
+	for _, sink := range l.Sinks {
+		if !containsOutput(outputs, sink) {
+			outputs = append(outputs, sink)
+		}
+	}
+	return outputs
+}
+
+func containsOutput(outputs []interface{}, output interface{}) bool {
+	if !reflect.TypeOf(output).Comparable() {
+		return false
+	}
+	for _, existing := range outputs {
+		if reflect.TypeOf(existing) == reflect.TypeOf(output) && existing == output {
+			return true
+		}
+	}
+	return false
+}
+
+func flushWriter(w interface{}) error {
+	if f, ok := w.(flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func closeWriter(w interface{}) error {
+	if w == os.Stdout || w == os.Stderr {
+		return nil
+	}
+	if c, ok := w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
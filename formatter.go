@@ -0,0 +1,249 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Format defines which of the built-in formatters is used to render the log messages
+type Format string
+
+const (
+	// FormatText renders messages as plain text, optionally prefixed with a timestamp and level
+	FormatText Format = "text"
+
+	// FormatJSON renders each message as a single line JSON object
+	FormatJSON Format = "json"
+
+	// FormatLogfmt renders each message as a single line of logfmt key/value pairs
+	FormatLogfmt Format = "logfmt"
+)
+
+// Formatter renders an entry to the bytes which are written to the output
+type Formatter interface {
+	Format(entry *Entry) ([]byte, error)
+}
+
+// TextFormatter renders entries as plain text
+//
+// When PrintTimestamp is enabled, the message is prefixed with the time and level. When PrintColors is enabled, each
+// line is colored according to the level.
+type TextFormatter struct{}
+
+// Format renders the entry as plain text
+func (f *TextFormatter) Format(entry *Entry) ([]byte, error) {
+
+	message := entry.Message
+
+	if len(entry.Fields) > 0 {
+		message = message + " " + formatFields(entry.Fields)
+	}
+
+	if entry.Caller != nil {
+		message = entry.Logger.formatCaller(entry.Caller) + " | " + message
+	}
+
+	if entry.Logger.PrintTimestamp && !entry.Time.IsZero() {
+		message = entry.FormattedTime() + " | " + entry.Level.label() + " | " + message
+	}
+
+	c := entry.Level.color()
+	if !entry.Logger.PrintColors || runtime.GOOS == "windows" || c == nil {
+		return []byte(message + "\n"), nil
+	}
+
+	var buf bytes.Buffer
+	for _, line := range splitInLines(message) {
+		buf.WriteString(c.Sprint(line))
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+
+}
+
+// JSONFormatter renders each entry as a single line JSON object
+//
+// Fields which use one of the keys of the entry itself ("time", "level", "caller", "func" and "msg") are prefixed with
+// "fields." so that they don't overwrite them.
+type JSONFormatter struct{}
+
+var jsonReservedKeys = []string{"time", "level", "caller", "func", "msg"}
+
+// Format renders the entry as a JSON object
+func (f *JSONFormatter) Format(entry *Entry) ([]byte, error) {
+
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	if !entry.Time.IsZero() {
+		buf.WriteString(`"time":`)
+		writeJSONValue(&buf, entry.FormattedTime())
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"level":`)
+	writeJSONValue(&buf, entry.Level.name())
+	if entry.Caller != nil {
+		buf.WriteString(`,"caller":`)
+		writeJSONValue(&buf, formatCallerFile(entry.Caller))
+		if entry.Logger.PrintCallerFunction {
+			buf.WriteString(`,"func":`)
+			writeJSONValue(&buf, formatCallerFunction(entry.Caller))
+		}
+	}
+	buf.WriteString(`,"msg":`)
+	writeJSONValue(&buf, entry.Message)
+
+	for _, field := range entry.Fields {
+		buf.WriteByte(',')
+		writeJSONValue(&buf, fieldKey(field.Key, jsonReservedKeys))
+		buf.WriteByte(':')
+		writeJSONValue(&buf, jsonFieldValue(field.Value))
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.Bytes(), nil
+
+}
+
+func jsonFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case error:
+		return v.Error()
+	case time.Duration:
+		return v.String()
+	case json.Marshaler:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
+
+func writeJSONValue(buf *bytes.Buffer, value interface{}) {
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		encoded.Reset()
+		encoder.Encode(fmt.Sprint(value))
+	}
+	buf.Write(bytes.TrimSuffix(encoded.Bytes(), []byte("\n")))
+}
+
+// LogfmtFormatter renders each entry as a single line of logfmt key/value pairs
+//
+// Fields which use one of the keys of the entry itself ("ts", "level", "caller", "func" and "msg") are prefixed with
+// "fields." so that they don't overwrite them.
+type LogfmtFormatter struct{}
+
+var logfmtReservedKeys = []string{"ts", "level", "caller", "func", "msg"}
+
+// Format renders the entry as a logfmt line
+func (f *LogfmtFormatter) Format(entry *Entry) ([]byte, error) {
+
+	var buf bytes.Buffer
+
+	if !entry.Time.IsZero() {
+		writeLogfmtPair(&buf, "ts", entry.FormattedTime())
+		buf.WriteByte(' ')
+	}
+	writeLogfmtPair(&buf, "level", entry.Level.name())
+	if entry.Caller != nil {
+		buf.WriteByte(' ')
+		writeLogfmtPair(&buf, "caller", formatCallerFile(entry.Caller))
+		if entry.Logger.PrintCallerFunction {
+			buf.WriteByte(' ')
+			writeLogfmtPair(&buf, "func", formatCallerFunction(entry.Caller))
+		}
+	}
+	buf.WriteByte(' ')
+	writeLogfmtPair(&buf, "msg", entry.Message)
+
+	for _, field := range entry.Fields {
+		buf.WriteByte(' ')
+		writeLogfmtPair(&buf, fieldKey(field.Key, logfmtReservedKeys), formatFieldValue(field.Value))
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+
+}
+
+// fieldKey returns the key of a field, prefixed with "fields." if it's one of the reserved keys
+func fieldKey(key string, reserved []string) string {
+	for _, r := range reserved {
+		if key == r {
+			return "fields." + key
+		}
+	}
+	return key
+}
+
+func writeLogfmtPair(buf *bytes.Buffer, key string, value string) {
+	buf.WriteString(logfmtKey(key))
+	buf.WriteByte('=')
+	buf.WriteString(logfmtValue(value))
+}
+
+func logfmtKey(key string) string {
+	if key == "" {
+		return badKey
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+func logfmtValue(value string) string {
+
+	if !logfmtNeedsQuotes(value) {
+		return value
+	}
+
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '\\', '"':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < ' ' || r == 0x7f {
+				fmt.Fprintf(&buf, `\u%04x`, r)
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+
+	return buf.String()
+
+}
+
+func logfmtNeedsQuotes(value string) bool {
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f || r == utf8.RuneError {
+			return true
+		}
+	}
+	return false
+}
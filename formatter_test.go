@@ -0,0 +1,245 @@
+package log_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONFormat(t *testing.T) {
+
+	type test struct {
+		name           string
+		fn             func(l *log.Logger)
+		expectedStdout string
+		expectedStderr string
+	}
+
+	var tests = []test{
+		{"debug", func(l *log.Logger) { l.Debug("debug") }, `{"time":"test","level":"debug","msg":"debug"}` + "\n", ""},
+		{"info", func(l *log.Logger) { l.Infof("info %d", 1) }, `{"time":"test","level":"info","msg":"info 1"}` + "\n", ""},
+		{"warn", func(l *log.Logger) { l.Warn("<warn> & \"quotes\"") }, `{"time":"test","level":"warn","msg":"<warn> & \"quotes\""}` + "\n", ""},
+		{"error", func(l *log.Logger) { l.Error("error") }, "", `{"time":"test","level":"error","msg":"error"}` + "\n"},
+		{"dump", func(l *log.Logger) { l.InfoDump(map[string]string{"hello": "world"}, "") }, `{"time":"test","level":"info","msg":"map[string]string{\n  \"hello\": \"world\",\n}"}` + "\n", ""},
+		{"fields", func(l *log.Logger) {
+			l.With("user_id", 42, "name", "john", log.Err(errors.New("boom")), log.Duration("took", time.Second), log.Bool("ok", true)).Info("login")
+		}, `{"time":"test","level":"info","msg":"login","user_id":42,"name":"john","error":"boom","took":"1s","ok":true}` + "\n", ""},
+		{"reserved", func(l *log.Logger) {
+			l.With("time", 1, "level", "x", "msg", "dup", "caller", "c", "func", "f").Info("hi")
+		}, `{"time":"test","level":"info","msg":"hi","fields.time":1,"fields.level":"x","fields.msg":"dup","fields.caller":"c","fields.func":"f"}` + "\n", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			logger, stdout, stderr := newTestLogger()
+			logger.DebugMode = true
+			logger.PrintColors = true
+			logger.OutputFormat = log.FormatJSON
+
+			tc.fn(logger)
+
+			assert.Equal(t, tc.expectedStdout, stdout.String(), "stdout")
+			assert.Equal(t, tc.expectedStderr, stderr.String(), "stderr")
+
+		})
+	}
+
+}
+
+func TestJSONFormatStackTrace(t *testing.T) {
+
+	logger, _, stderr := newTestLogger()
+	logger.OutputFormat = log.FormatJSON
+
+	logger.StackTrace(errors.New("my error"))
+
+	lines := strings.Split(strings.TrimSuffix(stderr.String(), "\n"), "\n")
+	assert.Len(t, lines, 1)
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "error", record["level"])
+	assert.True(t, strings.HasPrefix(record["msg"].(string), "my error\n"))
+
+}
+
+func TestJSONFormatTimeZone(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.OutputFormat = log.FormatJSON
+	logger.TimeZone = time.UTC
+	logger.TimeFormat = "MST"
+
+	logger.Info("info")
+
+	assert.Equal(t, `{"time":"UTC","level":"info","msg":"info"}`+"\n", stdout.String())
+
+}
+
+func TestLogfmtFormat(t *testing.T) {
+
+	type test struct {
+		name           string
+		fn             func(l *log.Logger)
+		expectedStdout string
+		expectedStderr string
+	}
+
+	var tests = []test{
+		{"debug", func(l *log.Logger) { l.Debug("debug") }, "ts=test level=debug msg=debug\n", ""},
+		{"info", func(l *log.Logger) { l.Infof("info %d", 1) }, "ts=test level=info msg=\"info 1\"\n", ""},
+		{"warn", func(l *log.Logger) { l.Warn(`a "quoted" \ value`) }, `ts=test level=warn msg="a \"quoted\" \\ value"` + "\n", ""},
+		{"error", func(l *log.Logger) { l.Error("a=b") }, "", "ts=test level=error msg=\"a=b\"\n"},
+		{"dump", func(l *log.Logger) { l.InfoDump(map[string]string{"hello": "world"}, "") }, `ts=test level=info msg="map[string]string{\n  \"hello\": \"world\",\n}"` + "\n", ""},
+		{"control", func(l *log.Logger) { l.Info("a\tb\x01") }, `ts=test level=info msg="a\tb\u0001"` + "\n", ""},
+		{"fields", func(l *log.Logger) {
+			l.With("user_id", 42, "name", "john doe", "empty", "", "bad key", 1, log.Err(errors.New("boom"))).Info("login")
+		}, `ts=test level=info msg=login user_id=42 name="john doe" empty= bad_key=1 error=boom` + "\n", ""},
+		{"reserved", func(l *log.Logger) {
+			l.With("ts", 1, "level", "x", "msg", "dup", "caller", "c", "func", "f", "time", "t").Info("hi")
+		}, `ts=test level=info msg=hi fields.ts=1 fields.level=x fields.msg=dup fields.caller=c fields.func=f time=t` + "\n", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			logger, stdout, stderr := newTestLogger()
+			logger.DebugMode = true
+			logger.PrintColors = true
+			logger.OutputFormat = log.FormatLogfmt
+
+			tc.fn(logger)
+
+			assert.Equal(t, tc.expectedStdout, stdout.String(), "stdout")
+			assert.Equal(t, tc.expectedStderr, stderr.String(), "stderr")
+
+		})
+	}
+
+}
+
+func TestLogfmtFormatStackTrace(t *testing.T) {
+
+	logger, _, stderr := newTestLogger()
+	logger.OutputFormat = log.FormatLogfmt
+
+	logger.StackTrace(errors.New("my error"))
+
+	actual := stderr.String()
+	assert.Equal(t, 1, strings.Count(actual, "\n"))
+	assert.True(t, strings.HasPrefix(actual, `ts=test level=error msg="my error\n`), actual)
+
+}
+
+type testFormatter struct {
+	entries []*log.Entry
+}
+
+func (f *testFormatter) Format(entry *log.Entry) ([]byte, error) {
+	f.entries = append(f.entries, entry)
+	return []byte(fmt.Sprintf("[%s] %s %d\n", entry.Level, entry.Message, len(entry.Fields))), nil
+}
+
+type failingFormatter struct{}
+
+func (f *failingFormatter) Format(entry *log.Entry) ([]byte, error) {
+	return nil, errors.New("boom")
+}
+
+func TestCustomFormatter(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+	logger.PrintCaller = true
+
+	formatter := &testFormatter{}
+	logger.Formatter = formatter
+	logger.OutputFormat = log.FormatJSON
+
+	myErr := errors.New("my error")
+
+	logger.With("a", 1, "b", 2).Info("info")
+	logger.StackTrace(myErr)
+
+	assert.Equal(t, "[INFO] info 2\n", stdout.String())
+	assert.True(t, strings.HasPrefix(stderr.String(), "[ERROR] my error\n"))
+
+	assert.Len(t, formatter.entries, 2)
+	assert.Equal(t, logger.TimeFormat, formatter.entries[0].Logger.TimeFormat)
+	assert.Equal(t, log.InfoLevel, formatter.entries[0].Level)
+	assert.False(t, formatter.entries[0].Time.IsZero())
+	assert.Equal(t, []log.Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}}, formatter.entries[0].Fields)
+	assert.NotNil(t, formatter.entries[0].Caller)
+	assert.Nil(t, formatter.entries[0].Error)
+	assert.Equal(t, myErr, formatter.entries[1].Error)
+
+}
+
+func TestSetFormatter(t *testing.T) {
+
+	resetLogConfig()
+	stdout, _ := redirectOutput()
+	defer resetLogOutput()
+	defer log.SetFormatter(nil)
+
+	log.SetFormatter(&testFormatter{})
+	log.Info("info")
+
+	log.SetFormatter(nil)
+	log.PrintColors = false
+	log.Info("info")
+
+	assert.Equal(t, "[INFO] info 0\ntest | INFO  | info\n", stdout.String())
+
+}
+
+func TestFormatterError(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.Formatter = &failingFormatter{}
+
+	logger.Info("info")
+
+	assert.Equal(t, "failed to format log message: boom: info\n", stdout.String())
+
+}
+
+func TestEntryFormattedTime(t *testing.T) {
+
+	logger := log.New()
+	logger.TimeZone = time.UTC
+	logger.TimeFormat = time.RFC3339
+
+	entry := &log.Entry{
+		Logger: logger,
+		Time:   time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600)),
+	}
+
+	assert.Equal(t, "2020-01-02T02:04:05Z", entry.FormattedTime())
+
+}
+
+func TestEntryFormattedTimeDefaultFormat(t *testing.T) {
+
+	entry := &log.Entry{
+		Logger: &log.Logger{TimeZone: time.UTC},
+		Time:   time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	assert.Equal(t, "2020-01-02 03:04:05.000", entry.FormattedTime())
+
+	data, err := (&log.JSONFormatter{}).Format(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"time":"2020-01-02 03:04:05.000","level":"trace","msg":""}`+"\n", string(data))
+
+	data, err = (&log.LogfmtFormatter{}).Format(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, `ts="2020-01-02 03:04:05.000" level=trace msg=`+"\n", string(data))
+
+}
@@ -1,13 +1,22 @@
 module github.com/pieterclaerhout/go-log
 
-go 1.14
+go 1.21
 
 require (
 	github.com/fatih/color v1.18.0
-	github.com/kr/pretty v0.3.1 // indirect
 	github.com/pkg/errors v0.9.1
 	github.com/rotisserie/eris v0.5.4
 	github.com/sanity-io/litter v1.5.8
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/sys v0.25.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/pretty v0.3.1 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,320 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Logger is a logger which carries its own configuration
+//
+// Use New to create a logger with the default settings. The package-level functions such as Info and Debug use a
+// logger which is configured through the package-level variables.
+type Logger struct {
+
+	// PrintTimestamp indicates if the log messages should include a timestamp or not
+	PrintTimestamp bool
+
+	// PrintColors indicates if the messages should be printed in color or not
+	PrintColors bool
+
+	// PrintCaller indicates if the file and line of the caller should be included in the messages or not
+	PrintCaller bool
+
+	// PrintCallerFunction indicates if the function name of the caller should be included as well
+	PrintCallerFunction bool
+
+	// CollapseDuplicates indicates if identical consecutive messages should be printed only once
+	//
+	// The number of times the message was repeated is printed when a different message arrives or when Flush is called.
+	CollapseDuplicates bool
+
+	// DebugMode indicates if debug information should be printed or not
+	DebugMode bool
+
+	// TraceMode indicates if trace information should be printed or not, it implies DebugMode
+	TraceMode bool
+
+	// MinLevel is the minimum level a message needs to have to be printed
+	MinLevel Level
+
+	// TimeZone indicates in which timezone the time should be formatted
+	TimeZone *time.Location
+
+	// Stdout is the writer to where the stdout messages should be written
+	Stdout io.Writer
+
+	// Stderr is the writer to where the stderr messages should be written
+	Stderr io.Writer
+
+	// Routing defines to which writer the messages of each level are written (defaults to DefaultRouting)
+	Routing RoutingTable
+
+	// TimeFormat is the format to use for the timestamps
+	TimeFormat string
+
+	// TimestampMode defines if the timestamps are absolute or relative (defaults to TimeAbsolute)
+	TimestampMode TimeMode
+
+	// Clock returns the time which is used for the timestamps (defaults to time.Now)
+	Clock func() time.Time
+
+	// StartTime is the time from which TimeElapsed measures the elapsed time (defaults to the time the process
+	// started)
+	//
+	// It is measured with time.Now, so when you replace Clock, set StartTime using the new clock as well.
+	StartTime time.Time
+
+	// OutputFormat is the format in which the messages are written
+	OutputFormat Format
+
+	// Formatter renders the messages, overrides OutputFormat when set (optional)
+	Formatter Formatter
+
+	// Sinks are the outputs to which the messages are written instead of Stdout and Stderr (optional)
+	Sinks []Sink
+
+	// Sampler limits the number of repeated messages which are logged (optional)
+	Sampler *Sampler
+
+	// SlogBackend is the slog.Handler to which the messages are sent instead of being printed (optional)
+	SlogBackend slog.Handler
+
+	// OsExit is the function to exit the app when a fatal error happens
+	OsExit func(code int)
+
+	fields          []Field
+	callerSkip      int
+	duplicates      *duplicateTracker
+	timestamps      *timeTracker
+	exitInterceptor func(code int)
+}
+
+// New returns a new logger with the default settings
+//
+// DebugMode, TraceMode, PrintTimestamp, PrintCaller, MinLevel, OutputFormat, Routing and TimeZone are taken from the
+// DEBUG, TRACE, PRINT_TIMESTAMP, PRINT_CALLER, LOG_LEVEL, LOG_FORMAT, LOG_ROUTING and LOG_TIMEZONE environment
+// variables.
+func New() *Logger {
+	return &Logger{
+		PrintTimestamp: os.Getenv("PRINT_TIMESTAMP") == "1",
+		PrintCaller:    os.Getenv("PRINT_CALLER") == "1",
+		DebugMode:      os.Getenv("DEBUG") == "1",
+		TraceMode:      os.Getenv("TRACE") == "1",
+		MinLevel:       levelFromEnv(),
+		TimeZone:       timeZoneFromEnv(),
+		Stdout:         os.Stdout,
+		Stderr:         os.Stderr,
+		Routing:        routingFromEnv(),
+		TimeFormat:     DefaultTimeFormat,
+		Clock:          time.Now,
+		StartTime:      processStart,
+		OutputFormat:   formatFromEnv(),
+		OsExit:         os.Exit,
+		duplicates:     &duplicateTracker{},
+		timestamps:     &timeTracker{},
+	}
+}
+
+// Enabled returns true if messages with the given level will be printed
+//
+// Trace messages are only printed if TraceMode is set to true, debug messages if DebugMode or TraceMode is set to true.
+func (l *Logger) Enabled(level Level) bool {
+	return levelEnabled(level, l.MinLevel, l.DebugMode, l.TraceMode)
+}
+
+// Trace prints a trace message
+//
+// Only shown if TraceMode is set to true
+func (l *Logger) Trace(args ...interface{}) {
+	if l.Enabled(TraceLevel) {
+		message := formatMessage(args...)
+		l.log(TraceLevel, message)
+	}
+}
+
+// Tracef prints a trace message with a format and arguments
+//
+// Only shown if TraceMode is set to true
+func (l *Logger) Tracef(format string, args ...interface{}) {
+	if l.Enabled(TraceLevel) {
+		msg := fmt.Sprintf(format, args...)
+		l.Trace(msg)
+	}
+}
+
+// TraceSeparator prints a trace separator
+//
+// Only shown if TraceMode is set to true
+func (l *Logger) TraceSeparator(args ...interface{}) {
+	l.separator(TraceLevel, args...)
+}
+
+// TraceDump dumps the argument as a trace message with an optional prefix
+func (l *Logger) TraceDump(arg interface{}, prefix string) {
+	l.dump(TraceLevel, arg, prefix)
+}
+
+// Debug prints a debug message
+//
+// Only shown if DebugMode is set to true
+func (l *Logger) Debug(args ...interface{}) {
+	if l.Enabled(DebugLevel) {
+		message := formatMessage(args...)
+		l.log(DebugLevel, message)
+	}
+}
+
+// Debugf prints a debug message with a format and arguments
+//
+// Only shown if DebugMode is set to true
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	if l.Enabled(DebugLevel) {
+		msg := fmt.Sprintf(format, args...)
+		l.Debug(msg)
+	}
+}
+
+// DebugSeparator prints a debug separator
+//
+// Only shown if DebugMode is set to true
+func (l *Logger) DebugSeparator(args ...interface{}) {
+	l.separator(DebugLevel, args...)
+}
+
+// DebugDump dumps the argument as a debug message with an optional prefix
+func (l *Logger) DebugDump(arg interface{}, prefix string) {
+	l.dump(DebugLevel, arg, prefix)
+}
+
+// Info prints an info message
+func (l *Logger) Info(args ...interface{}) {
+	message := formatMessage(args...)
+	l.log(InfoLevel, message)
+}
+
+// Infof prints an info message with a format and arguments
+func (l *Logger) Infof(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.Info(msg)
+}
+
+// InfoSeparator prints an info separator
+func (l *Logger) InfoSeparator(args ...interface{}) {
+	l.separator(InfoLevel, args...)
+}
+
+// InfoDump dumps the argument as an info message with an optional prefix
+func (l *Logger) InfoDump(arg interface{}, prefix string) {
+	l.dump(InfoLevel, arg, prefix)
+}
+
+// Warn prints an warning message
+func (l *Logger) Warn(args ...interface{}) {
+	message := formatMessage(args...)
+	l.log(WarnLevel, message)
+}
+
+// Warnf prints a warning message with a format and arguments
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.Warn(msg)
+}
+
+// WarnSeparator prints a warning separator
+func (l *Logger) WarnSeparator(args ...interface{}) {
+	l.separator(WarnLevel, args...)
+}
+
+// WarnDump dumps the argument as a warning message with an optional prefix
+func (l *Logger) WarnDump(arg interface{}, prefix string) {
+	l.dump(WarnLevel, arg, prefix)
+}
+
+// Error prints an error message to stderr
+func (l *Logger) Error(args ...interface{}) {
+	message := formatMessage(args...)
+	l.log(ErrorLevel, message)
+}
+
+// Errorf prints an error message with a format and arguments
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.Error(msg)
+}
+
+// ErrorSeparator prints an error separator
+func (l *Logger) ErrorSeparator(args ...interface{}) {
+	l.separator(ErrorLevel, args...)
+}
+
+// ErrorDump dumps the argument as an err message with an optional prefix to stderr
+func (l *Logger) ErrorDump(arg interface{}, prefix string) {
+	l.dump(ErrorLevel, arg, prefix)
+}
+
+// StackTrace prints an error message with the stacktrace of err to stderr
+func (l *Logger) StackTrace(err error) {
+	l.stackTrace(ErrorLevel, err)
+}
+
+// Panic prints a panic message to stderr and panics with an error containing the message
+func (l *Logger) Panic(args ...interface{}) {
+	message := formatMessage(args...)
+	l.log(PanicLevel, message)
+	l.Flush()
+	panic(&loggedPanic{message: message})
+}
+
+// Panicf prints a panic message with a format and arguments and panics with an error containing the message
+func (l *Logger) Panicf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.Panic(msg)
+}
+
+// Fatal logs a fatal error message to stdout and exits the program with exit code 1
+func (l *Logger) Fatal(args ...interface{}) {
+	message := formatMessage(args...)
+	l.log(FatalLevel, message)
+	l.exit(1)
+}
+
+// Fatalf prints a fatal message with a format and arguments
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.Fatal(msg)
+}
+
+// CheckError checks if the error is not nil and if that's the case, it will print a fatal message and exits the
+// program with exit code 1.
+//
+// If the error implements ExitCoder, its exit code is used instead. If DebugMode is enabled a stack trace will also be
+// printed to stderr
+func (l *Logger) CheckError(err error) {
+
+	if err == nil {
+		return
+	}
+
+	if l.DebugMode {
+		l.stackTrace(FatalLevel, err)
+	} else {
+		l.log(FatalLevel, err.Error())
+	}
+
+	l.exit(exitCode(err))
+
+}
+
+// CheckErrorf checks if the error is not nil and if that's the case, it will print a fatal message which is prefixed
+// with the formatted context and exits the program
+//
+// The message is printed as "context: error". The exit code is determined in the same way as for CheckError.
+func (l *Logger) CheckErrorf(err error, format string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+	l.CheckError(fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err))
+}
@@ -0,0 +1,183 @@
+package log_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+
+	logger := log.New()
+
+	assert.NotNil(t, logger.Stdout)
+	assert.NotNil(t, logger.Stderr)
+	assert.NotNil(t, logger.OsExit)
+	assert.Equal(t, log.DefaultTimeFormat, logger.TimeFormat)
+
+}
+
+func TestLoggerIndependentConfig(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+
+	logger1, stdout1, stderr1 := newTestLogger()
+	logger1.DebugMode = true
+
+	logger2, stdout2, stderr2 := newTestLogger()
+	logger2.PrintTimestamp = false
+
+	logger1.Debug("debug")
+	logger2.Debug("debug")
+	logger2.Info("info")
+	log.Debug("debug")
+
+	assert.Equal(t, "test | DEBUG | debug\n", stdout1.String())
+	assert.Equal(t, "", stderr1.String())
+	assert.Equal(t, "info\n", stdout2.String())
+	assert.Equal(t, "", stderr2.String())
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "", stderr.String())
+
+}
+
+func TestLoggerMethods(t *testing.T) {
+
+	type test struct {
+		name           string
+		fn             func(l *log.Logger)
+		expectedStdout string
+		expectedStderr string
+	}
+
+	var tests = []test{
+		{"trace", func(l *log.Logger) { l.Trace("trace") }, "test | TRACE | trace\n", ""},
+		{"tracef", func(l *log.Logger) { l.Tracef("trace %d", 1) }, "test | TRACE | trace 1\n", ""},
+		{"trace-separator", func(l *log.Logger) { l.TraceSeparator("trace") }, "test | TRACE | ====[ trace ]===================================================================\n", ""},
+		{"trace-dump", func(l *log.Logger) { l.TraceDump("value", "prefix") }, "test | TRACE | prefix \"value\"\n", ""},
+		{"debug", func(l *log.Logger) { l.Debug("debug") }, "test | DEBUG | debug\n", ""},
+		{"debugf", func(l *log.Logger) { l.Debugf("debug %d", 1) }, "test | DEBUG | debug 1\n", ""},
+		{"debug-separator", func(l *log.Logger) { l.DebugSeparator("debug") }, "test | DEBUG | ====[ debug ]===================================================================\n", ""},
+		{"debug-dump", func(l *log.Logger) { l.DebugDump("value", "prefix") }, "test | DEBUG | prefix \"value\"\n", ""},
+		{"info", func(l *log.Logger) { l.Info("info") }, "test | INFO  | info\n", ""},
+		{"infof", func(l *log.Logger) { l.Infof("info %d", 1) }, "test | INFO  | info 1\n", ""},
+		{"info-dump", func(l *log.Logger) { l.InfoDump("value", "") }, "test | INFO  | \"value\"\n", ""},
+		{"warn", func(l *log.Logger) { l.Warn("warn") }, "test | WARN  | warn\n", ""},
+		{"warnf", func(l *log.Logger) { l.Warnf("warn %d", 1) }, "test | WARN  | warn 1\n", ""},
+		{"error", func(l *log.Logger) { l.Error("error") }, "", "test | ERROR | error\n"},
+		{"errorf", func(l *log.Logger) { l.Errorf("error %d", 1) }, "", "test | ERROR | error 1\n"},
+		{"error-separator", func(l *log.Logger) { l.ErrorSeparator("") }, "", "test | ERROR | ================================================================================\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			logger, stdout, stderr := newTestLogger()
+			logger.DebugMode = true
+			logger.TraceMode = true
+
+			tc.fn(logger)
+
+			assert.Equal(t, tc.expectedStdout, stdout.String(), "stdout")
+			assert.Equal(t, tc.expectedStderr, stderr.String(), "stderr")
+
+		})
+	}
+
+}
+
+func TestLoggerFatal(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+
+	var got int
+	logger.OsExit = func(code int) {
+		got = code
+	}
+
+	logger.Fatalf("fatal %d", 2)
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "test | FATAL | fatal 2\n", stderr.String())
+	assert.Equal(t, 1, got)
+
+}
+
+func TestLoggerCheckError(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+
+	got := -1
+	logger.OsExit = func(code int) {
+		got = code
+	}
+
+	logger.CheckError(nil)
+	assert.Equal(t, -1, got)
+
+	logger.CheckError(errors.New("test"))
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "test | FATAL | test\n", stderr.String())
+	assert.Equal(t, 1, got)
+
+}
+
+func TestLoggerClock(t *testing.T) {
+
+	type test struct {
+		name     string
+		timeZone string
+		expected string
+	}
+
+	var tests = []test{
+		{"utc", "UTC", "2020-01-02 03:04:05.000 | INFO  | info\n"},
+		{"brussels", "Europe/Brussels", "2020-01-02 04:04:05.000 | INFO  | info\n"},
+		{"new-york", "America/New_York", "2020-01-01 22:04:05.000 | INFO  | info\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			timeZone, err := time.LoadLocation(tc.timeZone)
+			assert.NoError(t, err)
+
+			logger, stdout, _ := newTestLogger()
+			logger.TimeZone = timeZone
+			logger.TimeFormat = log.DefaultTimeFormat
+			logger.Clock = newTestClock().Now
+
+			logger.Info("info")
+
+			assert.Equal(t, tc.expected, stdout.String())
+
+		})
+	}
+
+}
+
+func newTestLogger() (*log.Logger, *bytes.Buffer, *bytes.Buffer) {
+	stdout := bytes.NewBufferString("")
+	stderr := bytes.NewBufferString("")
+	logger := log.New()
+	logger.PrintTimestamp = true
+	logger.DebugMode = false
+	logger.TraceMode = false
+	logger.PrintCaller = false
+	logger.MinLevel = log.TraceLevel
+	logger.TimeFormat = log.TestingTimeFormat
+	logger.OutputFormat = log.FormatText
+	logger.Routing = nil
+	logger.Stdout = stdout
+	logger.Stderr = stderr
+	return logger, stdout, stderr
+}
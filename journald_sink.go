@@ -0,0 +1,165 @@
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// DefaultJournaldSocket is the path of the socket on which journald listens for native protocol messages
+const DefaultJournaldSocket = "/run/systemd/journal/socket"
+
+// JournaldSink is a sink which sends the entries to journald using its native protocol
+//
+// Each entry is sent with the MESSAGE, PRIORITY and SYSLOG_IDENTIFIER fields. When the caller is known, CODE_FILE,
+// CODE_LINE and CODE_FUNC are added as well. The fields of the entry are sent as custom journal fields with their
+// keys converted to uppercase. Entries which are too large for a single datagram are passed using a file descriptor.
+type JournaldSink struct {
+
+	// SocketPath is the path of the journald socket (defaults to DefaultJournaldSocket)
+	SocketPath string
+
+	// Identifier is sent as SYSLOG_IDENTIFIER (defaults to the name of the executable)
+	Identifier string
+
+	// MinLevel is the minimum level an entry needs to have to be sent
+	MinLevel Level
+
+	mutex sync.Mutex
+	conn  *net.UnixConn
+}
+
+// NewJournaldSink returns a sink which sends the entries to the local journald
+func NewJournaldSink() *JournaldSink {
+	return &JournaldSink{
+		SocketPath: DefaultJournaldSocket,
+		Identifier: filepath.Base(os.Args[0]),
+	}
+}
+
+// JournaldAvailable returns true if the journald socket exists
+func JournaldAvailable() bool {
+	_, err := os.Stat(DefaultJournaldSocket)
+	return err == nil
+}
+
+// Enabled returns true if level is at least the minimum level of the sink
+func (s *JournaldSink) Enabled(level Level) bool {
+	return level >= s.MinLevel
+}
+
+// Write sends the entry to journald
+func (s *JournaldSink) Write(entry *Entry) error {
+
+	data := s.encode(entry)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conn == nil {
+		conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	addr := &net.UnixAddr{Name: s.socketPath(), Net: "unixgram"}
+
+	_, _, err := s.conn.WriteMsgUnix(data, nil, addr)
+	if errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS) {
+		return sendJournaldFile(s.conn, addr, data)
+	}
+
+	return err
+
+}
+
+// Close closes the socket
+func (s *JournaldSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *JournaldSink) socketPath() string {
+	if s.SocketPath == "" {
+		return DefaultJournaldSocket
+	}
+	return s.SocketPath
+}
+
+func (s *JournaldSink) encode(entry *Entry) []byte {
+
+	var buf bytes.Buffer
+
+	writeJournaldField(&buf, "MESSAGE", entry.Message)
+	writeJournaldField(&buf, "PRIORITY", strconv.Itoa(syslogSeverity(entry.Level)))
+	if s.Identifier != "" {
+		writeJournaldField(&buf, "SYSLOG_IDENTIFIER", s.Identifier)
+	}
+
+	if entry.Caller != nil {
+		writeJournaldField(&buf, "CODE_FILE", entry.Caller.File)
+		writeJournaldField(&buf, "CODE_LINE", strconv.Itoa(entry.Caller.Line))
+		writeJournaldField(&buf, "CODE_FUNC", entry.Caller.Function)
+	}
+
+	for _, field := range entry.Fields {
+		if key := journaldKey(field.Key); key != "" {
+			writeJournaldField(&buf, key, formatFieldValue(field.Value))
+		}
+	}
+
+	return buf.Bytes()
+
+}
+
+// writeJournaldField writes a field using the simple format or, if the value contains a newline, the binary format
+func writeJournaldField(buf *bytes.Buffer, key string, value string) {
+	buf.WriteString(key)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journaldKey converts key to a valid journal field name
+//
+// Field names can only contain uppercase letters, digits and underscores, cannot start with an underscore or a digit
+// and are at most 64 characters long.
+func journaldKey(key string) string {
+	key = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	key = strings.TrimLeft(key, "_0123456789")
+	if len(key) > 64 {
+		key = key[:64]
+	}
+	return key
+}
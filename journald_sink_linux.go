@@ -0,0 +1,78 @@
+//go:build linux
+
+package log
+
+import (
+	"net"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// sendJournaldFile passes data to journald using a sealed memfd, or an unlinked temporary file if memfd isn't
+// available
+func sendJournaldFile(conn *net.UnixConn, addr *net.UnixAddr, data []byte) error {
+
+	file, err := journaldMemfd(data)
+	if err != nil {
+		file, err = journaldTempFile(data)
+		if err != nil {
+			return err
+		}
+	}
+	defer file.Close()
+
+	rights := syscall.UnixRights(int(file.Fd()))
+	_, _, err = conn.WriteMsgUnix(nil, rights, addr)
+
+	return err
+
+}
+
+func journaldMemfd(data []byte) (*os.File, error) {
+
+	fd, err := unix.MemfdCreate("journal-message", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return nil, err
+	}
+
+	file := os.NewFile(uintptr(fd), "journal-message")
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	seals := unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	if _, err := unix.FcntlInt(file.Fd(), unix.F_ADD_SEALS, seals); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return file, nil
+
+}
+
+func journaldTempFile(data []byte) (*os.File, error) {
+
+	file, err := os.CreateTemp("/dev/shm", "journal.")
+	if err != nil {
+		file, err = os.CreateTemp("", "journal.")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.Remove(file.Name()); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return file, nil
+
+}
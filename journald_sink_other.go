@@ -0,0 +1,13 @@
+//go:build !linux
+
+package log
+
+import (
+	"errors"
+	"net"
+)
+
+// sendJournaldFile is only supported on Linux
+func sendJournaldFile(conn *net.UnixConn, addr *net.UnixAddr, data []byte) error {
+	return errors.New("sending large journald entries is only supported on Linux")
+}
@@ -0,0 +1,167 @@
+//go:build linux
+
+package log_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournaldSink(t *testing.T) {
+
+	conn, path := listenJournald(t)
+
+	sink := log.NewJournaldSink()
+	sink.SocketPath = path
+	sink.Identifier = "myapp"
+	defer sink.Close()
+
+	entry := newTestEntry(log.WarnLevel, "disk almost full", log.Int("percent", 95), log.String("mount-point", "/var"), log.String("_hidden", "x"))
+	entry.Caller = &runtime.Frame{File: "/src/main.go", Line: 12, Function: "main.main"}
+
+	assert.NoError(t, sink.Write(entry))
+
+	fields := parseJournaldFields(t, readJournaldDatagram(t, conn))
+
+	assert.Equal(t, map[string]string{
+		"MESSAGE":           "disk almost full",
+		"PRIORITY":          "4",
+		"SYSLOG_IDENTIFIER": "myapp",
+		"CODE_FILE":         "/src/main.go",
+		"CODE_LINE":         "12",
+		"CODE_FUNC":         "main.main",
+		"PERCENT":           "95",
+		"MOUNT_POINT":       "/var",
+		"HIDDEN":            "x",
+	}, fields)
+
+}
+
+func TestJournaldSinkMultiline(t *testing.T) {
+
+	conn, path := listenJournald(t)
+
+	sink := log.NewJournaldSink()
+	sink.SocketPath = path
+	defer sink.Close()
+
+	assert.NoError(t, sink.Write(newTestEntry(log.ErrorLevel, "map[string]string{\n  \"hello\": \"world\",\n}")))
+
+	fields := parseJournaldFields(t, readJournaldDatagram(t, conn))
+
+	assert.Equal(t, "map[string]string{\n  \"hello\": \"world\",\n}", fields["MESSAGE"])
+	assert.Equal(t, "3", fields["PRIORITY"])
+
+}
+
+func TestJournaldSinkLargeEntry(t *testing.T) {
+
+	conn, path := listenJournald(t)
+
+	sink := log.NewJournaldSink()
+	sink.SocketPath = path
+	defer sink.Close()
+
+	message := strings.Repeat("0123456789abcdef\n", 256*1024)
+	assert.NoError(t, sink.Write(newTestEntry(log.InfoLevel, message)))
+
+	fields := parseJournaldFields(t, readJournaldDatagram(t, conn))
+
+	assert.Equal(t, message, fields["MESSAGE"])
+	assert.Equal(t, "6", fields["PRIORITY"])
+
+}
+
+func TestJournaldSinkEnabled(t *testing.T) {
+
+	sink := log.NewJournaldSink()
+	sink.MinLevel = log.InfoLevel
+
+	assert.False(t, sink.Enabled(log.DebugLevel))
+	assert.True(t, sink.Enabled(log.InfoLevel))
+
+}
+
+func listenJournald(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, path
+}
+
+// readJournaldDatagram reads a datagram, following the file descriptor if one was passed
+func readJournaldDatagram(t *testing.T, conn *net.UnixConn) []byte {
+
+	t.Helper()
+
+	buf := make([]byte, 256*1024)
+	oob := make([]byte, syscall.CmsgSpace(4))
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if oobn == 0 {
+		return buf[:n]
+	}
+
+	messages, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fds, err := syscall.ParseUnixRights(&messages[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := os.NewFile(uintptr(fds[0]), "journal")
+	defer file.Close()
+
+	data, err := io.ReadAll(io.NewSectionReader(file, 0, 1<<30))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+
+}
+
+func parseJournaldFields(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	fields := map[string]string{}
+	for len(data) > 0 {
+		idx := bytes.IndexAny(data, "=\n")
+		if idx < 0 {
+			t.Fatalf("invalid journald data: %q", data)
+		}
+		key := string(data[:idx])
+		if data[idx] == '=' {
+			end := bytes.IndexByte(data[idx:], '\n') + idx
+			fields[key] = string(data[idx+1 : end])
+			data = data[end+1:]
+			continue
+		}
+		length := binary.LittleEndian.Uint64(data[idx+1 : idx+9])
+		fields[key] = string(data[idx+9 : idx+9+int(length)])
+		data = data[idx+9+int(length)+1:]
+	}
+	return fields
+}
@@ -0,0 +1,108 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Level is the severity of a log message
+type Level int
+
+const (
+	// TraceLevel is used for very detailed debugging information
+	TraceLevel Level = iota
+
+	// DebugLevel is used for debugging information
+	DebugLevel
+
+	// InfoLevel is used for informational messages
+	InfoLevel
+
+	// WarnLevel is used for warnings
+	WarnLevel
+
+	// ErrorLevel is used for errors
+	ErrorLevel
+
+	// PanicLevel is used for panics
+	PanicLevel
+
+	// FatalLevel is used for fatal errors after which the program exits
+	FatalLevel
+)
+
+var levelNames = map[Level]string{
+	TraceLevel: "TRACE",
+	DebugLevel: "DEBUG",
+	InfoLevel:  "INFO",
+	WarnLevel:  "WARN",
+	ErrorLevel: "ERROR",
+	PanicLevel: "PANIC",
+	FatalLevel: "FATAL",
+}
+
+var levelColors = map[Level]*color.Color{
+	TraceLevel: color.New(color.FgHiCyan),
+	DebugLevel: color.New(color.FgHiBlack),
+	InfoLevel:  color.New(color.FgHiGreen),
+	WarnLevel:  color.New(color.FgHiYellow),
+	ErrorLevel: color.New(color.FgHiRed),
+	PanicLevel: color.New(color.FgHiRed),
+	FatalLevel: color.New(color.FgHiRed),
+}
+
+// ParseLevel returns the level with the given name
+//
+// The name is case-insensitive, "warning" is accepted as an alias for "warn".
+func ParseLevel(name string) (Level, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == "WARNING" {
+		return WarnLevel, nil
+	}
+	for level, levelName := range levelNames {
+		if levelName == name {
+			return level, nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("unknown log level: %q", name)
+}
+
+// String returns the name of the level in uppercase
+func (lvl Level) String() string {
+	if name, ok := levelNames[lvl]; ok {
+		return name
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(lvl))
+}
+
+// MarshalText returns the name of the level in lowercase
+func (lvl Level) MarshalText() ([]byte, error) {
+	if _, ok := levelNames[lvl]; !ok {
+		return nil, fmt.Errorf("unknown log level: %d", int(lvl))
+	}
+	return []byte(strings.ToLower(lvl.String())), nil
+}
+
+// UnmarshalText parses the level from its name
+func (lvl *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*lvl = level
+	return nil
+}
+
+func (lvl Level) label() string {
+	return fmt.Sprintf("%-5s", lvl.String())
+}
+
+func (lvl Level) name() string {
+	return strings.ToLower(lvl.String())
+}
+
+func (lvl Level) color() *color.Color {
+	return levelColors[lvl]
+}
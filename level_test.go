@@ -0,0 +1,118 @@
+package log_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevel(t *testing.T) {
+
+	type test struct {
+		name        string
+		input       string
+		expected    log.Level
+		expectError bool
+	}
+
+	var tests = []test{
+		{"trace", "trace", log.TraceLevel, false},
+		{"debug", "DEBUG", log.DebugLevel, false},
+		{"info", "Info", log.InfoLevel, false},
+		{"warn", "warn", log.WarnLevel, false},
+		{"warning", "warning", log.WarnLevel, false},
+		{"error", " error ", log.ErrorLevel, false},
+		{"panic", "PANIC", log.PanicLevel, false},
+		{"fatal", "fatal", log.FatalLevel, false},
+		{"invalid", "verbose", log.InfoLevel, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := log.ParseLevel(tc.input)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+}
+
+func TestLevelString(t *testing.T) {
+
+	assert.Equal(t, "TRACE", log.TraceLevel.String())
+	assert.Equal(t, "INFO", log.InfoLevel.String())
+	assert.Equal(t, "FATAL", log.FatalLevel.String())
+	assert.Equal(t, "LEVEL(42)", log.Level(42).String())
+
+}
+
+func TestLevelMarshalText(t *testing.T) {
+
+	data, err := json.Marshal(map[string]log.Level{"level": log.WarnLevel})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"level":"warn"}`, string(data))
+
+	_, err = log.Level(42).MarshalText()
+	assert.Error(t, err)
+
+	var parsed struct {
+		Level log.Level `json:"level"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"level":"error"}`), &parsed))
+	assert.Equal(t, log.ErrorLevel, parsed.Level)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"level":"unknown"}`), &parsed))
+
+}
+
+func TestMinLevel(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+	log.DebugMode = true
+	log.MinLevel = log.WarnLevel
+
+	log.Debug("debug")
+	log.Info("info")
+	log.InfoSeparator("info")
+	log.Warn("warn")
+	log.Error("error")
+
+	assert.Equal(t, "test | WARN  | warn\n", stdout.String())
+	assert.Equal(t, "test | ERROR | error\n", stderr.String())
+
+}
+
+func TestEnabled(t *testing.T) {
+
+	logger := log.New()
+
+	logger.DebugMode = false
+	logger.MinLevel = log.TraceLevel
+	assert.False(t, logger.Enabled(log.DebugLevel))
+	assert.True(t, logger.Enabled(log.InfoLevel))
+
+	logger.DebugMode = true
+	assert.True(t, logger.Enabled(log.DebugLevel))
+	assert.False(t, logger.Enabled(log.TraceLevel))
+
+	logger.DebugMode = false
+	logger.TraceMode = true
+	assert.True(t, logger.Enabled(log.TraceLevel))
+	assert.True(t, logger.Enabled(log.DebugLevel))
+
+	logger.MinLevel = log.ErrorLevel
+	assert.False(t, logger.Enabled(log.WarnLevel))
+	assert.True(t, logger.Enabled(log.ErrorLevel))
+	assert.True(t, logger.Enabled(log.FatalLevel))
+
+}
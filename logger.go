@@ -9,22 +9,51 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/rotisserie/eris"
-	"github.com/sanity-io/litter"
 )
 
 // PrintTimestamp indicates if the log messages should include a timestamp or not
 var PrintTimestamp = false
 
+// PrintCaller indicates if the file and line of the caller should be included in the messages or not
+//
+// If the environment variable called PRINT_CALLER is set to 1, this will default to true.
+var PrintCaller = false
+
+// PrintCallerFunction indicates if the function name of the caller should be included as well
+var PrintCallerFunction = false
+
 // PrintColors indicates if the messages should be printed in color or not
 var PrintColors = false
 
+// CollapseDuplicates indicates if identical consecutive messages should be printed only once
+//
+// The number of times the message was repeated is printed as "(repeated 49 times)" when a different message arrives or
+// when Flush is called.
+var CollapseDuplicates = false
+
 // DebugMode indicates if debug information should be printed or not
 //
 // If the environment variable called DEBUG is set to 1, this will default to true.
 // In all other cases, debug mode is false by default.
 var DebugMode = false
 
-// TimeZone indicates in which timezone the time should be formatted
+// TraceMode indicates if trace information should be printed or not
+//
+// Enabling it prints the debug information as well. If the environment variable called TRACE is set to 1, this will
+// default to true. In all other cases, trace mode is false by default.
+var TraceMode = false
+
+// MinLevel is the minimum level a message needs to have to be printed (defaults to TraceLevel)
+//
+// Trace and debug messages are only printed if TraceMode or DebugMode is set to true as well. If the environment
+// variable called LOG_LEVEL is set to a valid level name, it is used as the default.
+var MinLevel = TraceLevel
+
+// TimeZone indicates in which timezone the time should be formatted (defaults to the local time zone)
+//
+// The local time zone honours the TZ environment variable. If the environment variable called LOG_TIMEZONE is set to a
+// valid time zone name, "UTC" or "Local", it is used instead. Build with -tags log_tzdata to embed the time zone
+// database for systems which don't have it installed.
 var TimeZone *time.Location
 
 // Stdout is the writer to where the stdout messages should be written (defaults to os.Stdout)
@@ -33,6 +62,12 @@ var Stdout io.Writer = os.Stdout
 // Stderr is the writer to where the stderr messages should be written (defaults to os.Stderr)
 var Stderr io.Writer = os.Stderr
 
+// Routing defines to which writer the messages of each level are written (defaults to DefaultRouting)
+//
+// If the environment variable called LOG_ROUTING is set to a valid preset name, it is used as the default. See
+// ParseRouting for the valid names.
+var Routing RoutingTable
+
 // DefaultTimeFormat is the default format to use for the timestamps
 var DefaultTimeFormat = "2006-01-02 15:04:05.000"
 
@@ -42,16 +77,79 @@ var TestingTimeFormat = "test"
 // TimeFormat is the format to use for the timestamps
 var TimeFormat = DefaultTimeFormat
 
+// TimestampMode defines if the timestamps are absolute or relative (defaults to TimeAbsolute)
+//
+// Use TimeElapsed to print the time since the process started or TimeDelta to print the time since the previous
+// message. TimeZone and TimeFormat are only used for absolute timestamps.
+var TimestampMode = TimeAbsolute
+
+// Clock returns the time which is used for the timestamps (defaults to time.Now)
+//
+// Replace it to get deterministic timestamps in tests or to stamp messages with historic times.
+var Clock = time.Now
+
+// StartTime is the time from which TimeElapsed measures the elapsed time (defaults to the time the process started)
+//
+// It is measured with time.Now, so when you replace Clock, set StartTime using the new clock as well.
+var StartTime = processStart
+
+// OutputFormat is the format in which the messages are written (defaults to FormatText)
+//
+// If the environment variable called LOG_FORMAT is set, it is used as the default.
+var OutputFormat = FormatText
+
+var formatter Formatter
+
+// SetFormatter sets the formatter which renders the messages of the package-level functions
+//
+// It overrides OutputFormat. Pass nil to use the formatter for OutputFormat again.
+func SetFormatter(f Formatter) {
+	formatter = f
+}
+
 // OsExit is the function to exit the app when a fatal error happens
 var OsExit = os.Exit
 
+// Trace prints a trace message
+//
+// Only shown if TraceMode is set to true
+func Trace(args ...interface{}) {
+	if enabled(TraceLevel) {
+		defaultLogger().Trace(args...)
+	}
+}
+
+// Tracef prints a trace message with a format and arguments
+//
+// Only shown if TraceMode is set to true
+func Tracef(format string, args ...interface{}) {
+	if enabled(TraceLevel) {
+		defaultLogger().Tracef(format, args...)
+	}
+}
+
+// TraceSeparator prints a trace separator
+//
+// Only shown if TraceMode is set to true
+func TraceSeparator(args ...interface{}) {
+	if enabled(TraceLevel) {
+		defaultLogger().TraceSeparator(args...)
+	}
+}
+
+// TraceDump dumps the argument as a trace message with an optional prefix
+func TraceDump(arg interface{}, prefix string) {
+	if enabled(TraceLevel) {
+		defaultLogger().TraceDump(arg, prefix)
+	}
+}
+
 // Debug prints a debug message
 //
 // Only shown if DebugMode is set to true
 func Debug(args ...interface{}) {
-	if DebugMode {
-		message := formatMessage(args...)
-		printMessage("DEBUG", message)
+	if enabled(DebugLevel) {
+		defaultLogger().Debug(args...)
 	}
 }
 
@@ -59,9 +157,8 @@ func Debug(args ...interface{}) {
 //
 // Only shown if DebugMode is set to true
 func Debugf(format string, args ...interface{}) {
-	if DebugMode {
-		msg := fmt.Sprintf(format, args...)
-		Debug(msg)
+	if enabled(DebugLevel) {
+		defaultLogger().Debugf(format, args...)
 	}
 }
 
@@ -69,114 +166,105 @@ func Debugf(format string, args ...interface{}) {
 //
 // Only shown if DebugMode is set to true
 func DebugSeparator(args ...interface{}) {
-	if DebugMode {
-		message := formatMessage(args...)
-		message = formatSeparator(message, "=", 80)
-		printMessage("DEBUG", message)
+	if enabled(DebugLevel) {
+		defaultLogger().DebugSeparator(args...)
 	}
 }
 
 // DebugDump dumps the argument as a debug message with an optional prefix
 func DebugDump(arg interface{}, prefix string) {
-	message := litter.Sdump(arg)
-	if prefix != "" {
-		Debug(prefix, message)
-	} else {
-		Debug(message)
+	if enabled(DebugLevel) {
+		defaultLogger().DebugDump(arg, prefix)
 	}
 }
 
 // Info prints an info message
 func Info(args ...interface{}) {
-	message := formatMessage(args...)
-	printMessage("INFO ", message)
+	if enabled(InfoLevel) {
+		defaultLogger().Info(args...)
+	}
 }
 
 // Infof prints an info message with a format and arguments
 func Infof(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	Info(msg)
+	if enabled(InfoLevel) {
+		defaultLogger().Infof(format, args...)
+	}
 }
 
 // InfoSeparator prints an info separator
 func InfoSeparator(args ...interface{}) {
-	message := formatMessage(args...)
-	message = formatSeparator(message, "=", 80)
-	printMessage("INFO ", message)
+	if enabled(InfoLevel) {
+		defaultLogger().InfoSeparator(args...)
+	}
 }
 
 // InfoDump dumps the argument as an info message with an optional prefix
 func InfoDump(arg interface{}, prefix string) {
-	message := litter.Sdump(arg)
-	if prefix != "" {
-		Info(prefix, message)
-	} else {
-		Info(message)
+	if enabled(InfoLevel) {
+		defaultLogger().InfoDump(arg, prefix)
 	}
 }
 
 // Warn prints an warning message
 func Warn(args ...interface{}) {
-	message := formatMessage(args...)
-	printMessage("WARN ", message)
+	if enabled(WarnLevel) {
+		defaultLogger().Warn(args...)
+	}
 }
 
 // Warnf prints a warning message with a format and arguments
 func Warnf(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	Warn(msg)
+	if enabled(WarnLevel) {
+		defaultLogger().Warnf(format, args...)
+	}
 }
 
 // WarnSeparator prints a warning separator
 func WarnSeparator(args ...interface{}) {
-	message := formatMessage(args...)
-	message = formatSeparator(message, "=", 80)
-	printMessage("WARN ", message)
+	if enabled(WarnLevel) {
+		defaultLogger().WarnSeparator(args...)
+	}
 }
 
 // WarnDump dumps the argument as a warning message with an optional prefix
 func WarnDump(arg interface{}, prefix string) {
-	message := litter.Sdump(arg)
-	if prefix != "" {
-		Warn(prefix, message)
-	} else {
-		Warn(message)
+	if enabled(WarnLevel) {
+		defaultLogger().WarnDump(arg, prefix)
 	}
 }
 
 // Error prints an error message to stderr
 func Error(args ...interface{}) {
-	message := formatMessage(args...)
-	printMessage("ERROR", message)
+	if enabled(ErrorLevel) {
+		defaultLogger().Error(args...)
+	}
 }
 
 // Errorf prints an error message with a format and arguments
 func Errorf(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	Error(msg)
+	if enabled(ErrorLevel) {
+		defaultLogger().Errorf(format, args...)
+	}
 }
 
 // ErrorSeparator prints an error separator
 func ErrorSeparator(args ...interface{}) {
-	message := formatMessage(args...)
-	message = formatSeparator(message, "=", 80)
-	printMessage("ERROR", message)
+	if enabled(ErrorLevel) {
+		defaultLogger().ErrorSeparator(args...)
+	}
 }
 
 // ErrorDump dumps the argument as an err message with an optional prefix to stderr
 func ErrorDump(arg interface{}, prefix string) {
-	message := litter.Sdump(arg)
-	if prefix != "" {
-		Error(prefix, message)
-	} else {
-		Error(message)
+	if enabled(ErrorLevel) {
+		defaultLogger().ErrorDump(arg, prefix)
 	}
 }
 
 // StackTrace prints an error message with the stacktrace of err to stderr
 func StackTrace(err error) {
-	message := formatMessage(FormattedStackTrace(err))
-	printMessage("ERROR", message)
+	defaultLogger().StackTrace(err)
 }
 
 // FormattedStackTrace returns a formatted stacktrace for err
@@ -213,36 +301,39 @@ func FormattedStackTrace(err error) string {
 
 }
 
+// Panic prints a panic message to stderr and panics with an error containing the message
+func Panic(args ...interface{}) {
+	defaultLogger().Panic(args...)
+}
+
+// Panicf prints a panic message with a format and arguments and panics with an error containing the message
+func Panicf(format string, args ...interface{}) {
+	defaultLogger().Panicf(format, args...)
+}
+
 // Fatal logs a fatal error message to stdout and exits the program with exit code 1
 func Fatal(args ...interface{}) {
-	message := formatMessage(args...)
-	printMessage("FATAL", message)
-	OsExit(1)
+	defaultLogger().Fatal(args...)
 }
 
 // Fatalf prints a fatal message with a format and arguments
 func Fatalf(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	Fatal(msg)
+	defaultLogger().Fatalf(format, args...)
 }
 
 // CheckError checks if the error is not nil and if that's the case, it will print a fatal message and exits the
 // program with exit code 1.
 //
-// If DebugMode is enabled a stack trace will also be printed to stderr
+// If the error implements ExitCoder, its exit code is used instead. If DebugMode is enabled a stack trace will also be
+// printed to stderr
 func CheckError(err error) {
+	defaultLogger().CheckError(err)
+}
 
-	if err == nil {
-		return
-	}
-
-	msg := err.Error()
-	if DebugMode {
-		msg = formatMessage(FormattedStackTrace(err))
-	}
-
-	printMessage("FATAL", msg)
-
-	OsExit(1)
-
+// CheckErrorf checks if the error is not nil and if that's the case, it will print a fatal message which is prefixed
+// with the formatted context and exits the program
+//
+// The message is printed as "context: error". The exit code is determined in the same way as for CheckError.
+func CheckErrorf(err error, format string, args ...interface{}) {
+	defaultLogger().CheckErrorf(err, format, args...)
 }
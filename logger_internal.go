@@ -2,33 +2,32 @@ package log
 
 import (
 	"fmt"
-	"io"
 	"os"
-	"runtime"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/sanity-io/litter"
 )
 
-var colors = map[string]*color.Color{
-	"DEBUG": color.New(color.FgHiBlack),
-	"INFO ": color.New(color.FgHiGreen),
-	"WARN ": color.New(color.FgHiYellow),
-	"ERROR": color.New(color.FgHiRed),
-	"FATAL": color.New(color.FgHiRed),
-}
-
 var mutex sync.Mutex
 
 func init() {
 
-	TimeZone, _ = time.LoadLocation("Europe/Brussels")
+	TimeZone = timeZoneFromEnv()
 	DebugMode = os.Getenv("DEBUG") == "1"
+	TraceMode = os.Getenv("TRACE") == "1"
 	PrintTimestamp = os.Getenv("PRINT_TIMESTAMP") == "1"
+	PrintCaller = os.Getenv("PRINT_CALLER") == "1"
+	MinLevel = levelFromEnv()
+	OutputFormat = formatFromEnv()
+	Routing = routingFromEnv()
 
 	color.NoColor = false
+	for _, c := range levelColors {
+		c.EnableColor()
+	}
 
 }
 
@@ -51,60 +50,209 @@ func formatSeparator(message string, separator string, length int) string {
 	return prefix + "[ " + message + " ]" + suffix
 }
 
-func printMessage(level string, message string) {
+// enabled returns if the package-level functions log messages of level, so that they can skip creating the logger
+func enabled(level Level) bool {
+	return levelEnabled(level, MinLevel, DebugMode, TraceMode)
+}
 
-	level = strings.ToUpper(level)
+func levelEnabled(level Level, minLevel Level, debugMode bool, traceMode bool) bool {
+	if level < minLevel {
+		return false
+	}
+	if level <= TraceLevel && !traceMode {
+		return false
+	}
+	if level <= DebugLevel && !debugMode && !traceMode {
+		return false
+	}
+	return true
+}
 
-	if PrintTimestamp {
-		message = addTimestampToMessage(level, message)
+func defaultLogger() *Logger {
+	return &Logger{
+		PrintTimestamp:      PrintTimestamp,
+		PrintColors:         PrintColors,
+		PrintCaller:         PrintCaller,
+		PrintCallerFunction: PrintCallerFunction,
+		CollapseDuplicates:  CollapseDuplicates,
+		DebugMode:           DebugMode,
+		TraceMode:           TraceMode,
+		MinLevel:            MinLevel,
+		TimeZone:            TimeZone,
+		Stdout:              Stdout,
+		Stderr:              Stderr,
+		Routing:             Routing,
+		TimeFormat:          TimeFormat,
+		TimestampMode:       TimestampMode,
+		Clock:               Clock,
+		StartTime:           StartTime,
+		OutputFormat:        OutputFormat,
+		Formatter:           formatter,
+		Sinks:               registeredSinks(),
+		Sampler:             sampler,
+		SlogBackend:         slogBackend,
+		OsExit:              OsExit,
+		duplicates:          duplicates,
+		timestamps:          timestamps,
+		exitInterceptor:     exitInterceptor,
 	}
+}
 
-	if PrintColors && runtime.GOOS != "windows" {
-		lines := splitInLines(message)
-		for _, line := range lines {
-			printColoredMessage(level, line)
-		}
+func (l *Logger) log(level Level, message string) {
+	if !l.Enabled(level) || !l.sample(level, message) {
+		return
+	}
+	if l.SlogBackend != nil {
+		l.logToSlog(level, message)
+		return
+	}
+	l.printMessage(level, message)
+}
+
+func (l *Logger) separator(level Level, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	title := formatMessage(args...)
+	line := formatSeparator(title, "=", 80)
+	if !l.sample(level, line) {
+		return
+	}
+	if l.SlogBackend != nil {
+		l.logToSlog(level, title, Bool("separator", true))
+		return
+	}
+	l.printMessage(level, line)
+}
+
+func (l *Logger) dump(level Level, arg interface{}, prefix string) {
+	if !l.Enabled(level) {
+		return
+	}
+	message := litter.Sdump(arg)
+	if !l.sample(level, prefix+message) {
+		return
+	}
+	if l.SlogBackend != nil {
+		l.logToSlog(level, formatMessage(prefix), String("dump", message))
+		return
+	}
+	if prefix != "" {
+		l.printMessage(level, formatMessage(prefix, message))
 	} else {
-		printNonColoredMessage(level, message)
+		l.printMessage(level, formatMessage(message))
 	}
+}
 
+func (l *Logger) stackTrace(level Level, err error) {
+	if !l.Enabled(level) || !l.sample(level, err.Error()) {
+		return
+	}
+	stackTrace := FormattedStackTrace(err)
+	if l.SlogBackend != nil {
+		l.logToSlog(level, err.Error(), String("stacktrace", stackTrace))
+		return
+	}
+	entry := l.newEntry(level, formatMessage(stackTrace))
+	entry.Error = err
+	entry.Stack = stackTrace
+	l.printEntry(entry)
 }
 
-func printNonColoredMessage(level string, message string) {
-	w := writerForLevel(level)
-	w.Write([]byte(message + "\n"))
+func (l *Logger) printMessage(level Level, message string) {
+	l.printEntry(l.newEntry(level, message))
 }
 
-func printColoredMessage(level string, message string) {
-	w := writerForLevel(level)
-	if c, ok := colors[level]; ok {
-		mutex.Lock()
-		defer mutex.Unlock()
-		c.EnableColor()
-		c.Fprint(w, message)
-		w.Write([]byte("\n"))
+func (l *Logger) newEntry(level Level, message string) *Entry {
+	entry := &Entry{
+		Logger:  l,
+		Time:    l.now(),
+		Level:   level,
+		Message: message,
+		Fields:  l.fields,
+		Caller:  l.caller(),
 	}
+	l.stamp(entry)
+	return entry
 }
 
-func addTimestampToMessage(level string, message string) string {
+func (l *Logger) printEntry(entry *Entry) {
+	if l.CollapseDuplicates && l.duplicates != nil {
+		l.duplicates.print(entry)
+		return
+	}
+	l.writeEntry(entry)
+}
+
+func (l *Logger) writeEntry(entry *Entry) {
+	if len(l.Sinks) > 0 {
+		l.writeToSinks(entry)
+		return
+	}
+	data, err := l.getFormatter().Format(entry)
+	if err != nil {
+		data = []byte(fmt.Sprintf("failed to format log message: %s: %s\n", err.Error(), entry.Message))
+	}
+	l.writeMessage(entry.Level, data)
+}
 
-	tstamp := time.Now()
-	if TimeZone != nil {
-		tstamp = tstamp.In(TimeZone)
+func (l *Logger) getFormatter() Formatter {
+	if l.Formatter != nil {
+		return l.Formatter
 	}
+	switch l.OutputFormat {
+	case FormatJSON:
+		return &JSONFormatter{}
+	case FormatLogfmt:
+		return &LogfmtFormatter{}
+	default:
+		return &TextFormatter{}
+	}
+}
 
-	formattedTime := tstamp.Format(TimeFormat)
-	message = formattedTime + " | " + level + " | " + message
+func (l *Logger) writeMessage(level Level, data []byte) {
+	w := l.writerForLevel(level)
+	mutex.Lock()
+	defer mutex.Unlock()
+	w.Write(data)
+}
 
-	return message
+func (l *Logger) now() time.Time {
+	if l.Clock != nil {
+		return l.Clock()
+	}
+	return time.Now()
+}
 
+func (l *Logger) exit(code int) {
+	if l.exitInterceptor != nil {
+		l.Flush()
+		l.exitInterceptor(code)
+		return
+	}
+	runExitHandlers()
+	l.Flush()
+	if l.OsExit != nil {
+		l.OsExit(code)
+		return
+	}
+	os.Exit(code)
+}
+
+func levelFromEnv() Level {
+	if name := os.Getenv("LOG_LEVEL"); name != "" {
+		if level, err := ParseLevel(name); err == nil {
+			return level
+		}
+	}
+	return TraceLevel
 }
 
-func writerForLevel(level string) io.Writer {
-	if level == "ERROR" || level == "FATAL" {
-		return Stderr
+func formatFromEnv() Format {
+	if format := os.Getenv("LOG_FORMAT"); format != "" {
+		return Format(strings.ToLower(format))
 	}
-	return Stdout
+	return FormatText
 }
 
 func splitInLines(text string) []string {
@@ -52,7 +52,7 @@ func TestPrintMessage_NoColor(t *testing.T) {
 
 	type test struct {
 		name           string
-		level          string
+		level          Level
 		message        string
 		printTimestamp bool
 		expectedStdout string
@@ -60,17 +60,17 @@ func TestPrintMessage_NoColor(t *testing.T) {
 	}
 
 	var tests = []test{
-		{"debug-1", "debug", "message", false, "message\n", ""},
-		{"debug-2", "debug", "message", true, TestingTimeFormat + " | DEBUG | message\n", ""},
+		{"debug-1", DebugLevel, "message", false, "message\n", ""},
+		{"debug-2", DebugLevel, "message", true, TestingTimeFormat + " | DEBUG | message\n", ""},
 
-		{"info-1", "info ", "message", false, "message\n", ""},
-		{"info-2", "info ", "message", true, TestingTimeFormat + " | INFO  | message\n", ""},
+		{"info-1", InfoLevel, "message", false, "message\n", ""},
+		{"info-2", InfoLevel, "message", true, TestingTimeFormat + " | INFO  | message\n", ""},
 
-		{"warn-1", "warn ", "message", false, "message\n", ""},
-		{"warn-2", "warn ", "message", true, TestingTimeFormat + " | WARN  | message\n", ""},
+		{"warn-1", WarnLevel, "message", false, "message\n", ""},
+		{"warn-2", WarnLevel, "message", true, TestingTimeFormat + " | WARN  | message\n", ""},
 
-		{"error-1", "error", "message", false, "", "message\n"},
-		{"error-2", "error", "message", true, "", TestingTimeFormat + " | ERROR | message\n"},
+		{"error-1", ErrorLevel, "message", false, "", "message\n"},
+		{"error-2", ErrorLevel, "message", true, "", TestingTimeFormat + " | ERROR | message\n"},
 	}
 
 	for _, tc := range tests {
@@ -83,7 +83,7 @@ func TestPrintMessage_NoColor(t *testing.T) {
 			PrintTimestamp = tc.printTimestamp
 			PrintColors = false
 
-			printMessage(tc.level, tc.message)
+			defaultLogger().printMessage(tc.level, tc.message)
 
 			actualStdOut := stdout.String()
 			actualStdErr := stderr.String()
@@ -100,7 +100,7 @@ func TestPrintMessage_Color(t *testing.T) {
 
 	type test struct {
 		name           string
-		level          string
+		level          Level
 		message        string
 		printTimestamp bool
 		expectedStdout string
@@ -108,17 +108,17 @@ func TestPrintMessage_Color(t *testing.T) {
 	}
 
 	var tests = []test{
-		{"debug-1", "debug", "message", false, "\x1b[90mmessage\x1b[0m\n", ""},
-		{"debug-2", "debug", "message", true, "\x1b[90m" + TestingTimeFormat + " | DEBUG | message\x1b[0m\n", ""},
+		{"debug-1", DebugLevel, "message", false, "\x1b[90mmessage\x1b[0m\n", ""},
+		{"debug-2", DebugLevel, "message", true, "\x1b[90m" + TestingTimeFormat + " | DEBUG | message\x1b[0m\n", ""},
 
-		{"info-1", "info ", "message", false, "\x1b[92mmessage\x1b[0m\n", ""},
-		{"info-2", "info ", "message", true, "\x1b[92m" + TestingTimeFormat + " | INFO  | message\x1b[0m\n", ""},
+		{"info-1", InfoLevel, "message", false, "\x1b[92mmessage\x1b[0m\n", ""},
+		{"info-2", InfoLevel, "message", true, "\x1b[92m" + TestingTimeFormat + " | INFO  | message\x1b[0m\n", ""},
 
-		{"warn-1", "warn ", "message", false, "\x1b[93mmessage\x1b[0m\n", ""},
-		{"warn-2", "warn ", "message", true, "\x1b[93m" + TestingTimeFormat + " | WARN  | message\x1b[0m\n", ""},
+		{"warn-1", WarnLevel, "message", false, "\x1b[93mmessage\x1b[0m\n", ""},
+		{"warn-2", WarnLevel, "message", true, "\x1b[93m" + TestingTimeFormat + " | WARN  | message\x1b[0m\n", ""},
 
-		{"error-1", "error", "message", false, "", "\x1b[91mmessage\x1b[0m\n"},
-		{"error-2", "error", "message", true, "", "\x1b[91m" + TestingTimeFormat + " | ERROR | message\x1b[0m\n"},
+		{"error-1", ErrorLevel, "message", false, "", "\x1b[91mmessage\x1b[0m\n"},
+		{"error-2", ErrorLevel, "message", true, "", "\x1b[91m" + TestingTimeFormat + " | ERROR | message\x1b[0m\n"},
 	}
 
 	for _, tc := range tests {
@@ -131,7 +131,7 @@ func TestPrintMessage_Color(t *testing.T) {
 			PrintTimestamp = tc.printTimestamp
 			PrintColors = true
 
-			printMessage(tc.level, tc.message)
+			defaultLogger().printMessage(tc.level, tc.message)
 
 			actualStdOut := stdout.String()
 			actualStdErr := stderr.String()
@@ -147,8 +147,13 @@ func TestPrintMessage_Color(t *testing.T) {
 func resetLogConfig() {
 	PrintTimestamp = false
 	DebugMode = false
+	TraceMode = false
+	PrintCaller = false
 	TimeZone, _ = time.LoadLocation("Europe/Brussels")
 	TimeFormat = TestingTimeFormat
+	MinLevel = TraceLevel
+	OutputFormat = FormatText
+	Routing = nil
 }
 
 func redirectOutput() (*bytes.Buffer, *bytes.Buffer) {
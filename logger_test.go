@@ -12,6 +12,102 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestTraceEnabled(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.TraceMode = true
+	log.PrintColors = false
+
+	log.Trace("trace")
+	log.Tracef("hello %d", 2)
+	log.Debug("debug")
+
+	actualStdOut := stdout.String()
+	actualStdErr := stderr.String()
+
+	assert.Equal(t, "test | TRACE | trace\ntest | TRACE | hello 2\ntest | DEBUG | debug\n", actualStdOut)
+	assert.Equal(t, "", actualStdErr)
+
+}
+
+func TestTraceDisabled(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.DebugMode = true
+	log.TraceMode = false
+
+	log.Trace("trace")
+	log.TraceSeparator("trace")
+	log.TraceDump("trace", "")
+
+	actualStdOut := stdout.String()
+	actualStdErr := stderr.String()
+
+	assert.Equal(t, "", actualStdOut)
+	assert.Equal(t, "", actualStdErr)
+
+}
+
+func TestTraceSeparatorEnabled(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.TraceMode = true
+	log.PrintColors = false
+
+	log.TraceSeparator("trace")
+
+	actualStdOut := stdout.String()
+	actualStdErr := stderr.String()
+
+	assert.Equal(t, "test | TRACE | ====[ trace ]===================================================================\n", actualStdOut)
+	assert.Equal(t, "", actualStdErr)
+
+}
+
+func TestTraceDumpWithPrefix(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.TraceMode = true
+	log.PrintColors = false
+
+	data := map[string]string{"hello": "world"}
+
+	log.TraceDump(data, "tprefix | ")
+
+	actualStdOut := stdout.String()
+	actualStdErr := stderr.String()
+
+	assert.Equal(t, "test | TRACE | tprefix |  map[string]string{\n  \"hello\": \"world\",\n}\n", actualStdOut)
+	assert.Equal(t, "", actualStdErr)
+
+}
+
+func TestTraceColor(t *testing.T) {
+
+	resetLogConfig()
+	stdout, _ := redirectOutput()
+	defer resetLogOutput()
+
+	log.TraceMode = true
+
+	log.Trace("trace")
+
+	assert.Equal(t, "\x1b[96mtest | TRACE | trace\x1b[0m\n", stdout.String())
+
+}
+
 func TestDebugEnabled(t *testing.T) {
 
 	resetLogConfig()
@@ -68,6 +164,26 @@ func TestDebugDisabled(t *testing.T) {
 
 }
 
+func TestDisabledAllocations(t *testing.T) {
+
+	resetLogConfig()
+	redirectOutput()
+	defer resetLogOutput()
+
+	log.DebugMode = false
+	log.TraceMode = false
+	log.MinLevel = log.WarnLevel
+
+	allocs := testing.AllocsPerRun(100, func() {
+		log.Trace("trace")
+		log.Debug("debug")
+		log.Info("info")
+	})
+
+	assert.Equal(t, float64(0), allocs)
+
+}
+
 func TestDebugSeparatorDisabled(t *testing.T) {
 
 	resetLogConfig()
@@ -593,12 +709,37 @@ func TestCheckError(t *testing.T) {
 
 }
 
+func TestClock(t *testing.T) {
+
+	resetLogConfig()
+	stdout, _ := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+	log.TimeZone = time.UTC
+	log.TimeFormat = time.RFC3339
+	log.Clock = func() time.Time {
+		return time.Date(2019, 12, 31, 23, 59, 59, 0, time.UTC)
+	}
+
+	log.Info("info")
+
+	assert.Equal(t, "2019-12-31T23:59:59Z | INFO  | info\n", stdout.String())
+
+}
+
 func resetLogConfig() {
 	log.PrintTimestamp = true
 	log.PrintColors = true
 	log.DebugMode = false
+	log.TraceMode = false
+	log.PrintCaller = false
 	log.TimeZone, _ = time.LoadLocation("Europe/Brussels")
 	log.TimeFormat = log.TestingTimeFormat
+	log.MinLevel = log.TraceLevel
+	log.OutputFormat = log.FormatText
+	log.Routing = nil
+	log.Clock = time.Now
 }
 
 func redirectOutput() (*bytes.Buffer, *bytes.Buffer) {
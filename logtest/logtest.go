@@ -0,0 +1,177 @@
+// Package logtest contains helpers to test the log messages printed by your code
+package logtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+)
+
+// Now is the fixed time which is used for the messages which are captured
+var Now = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// Entry is a log message which was captured by a Recorder
+type Entry struct {
+
+	// Time is the time of the message in UTC
+	Time time.Time
+
+	// Level is the level of the message
+	Level log.Level
+
+	// Message is the message
+	Message string
+
+	// Fields are the key/value pairs which were attached to the message
+	Fields map[string]interface{}
+}
+
+// Recorder records the messages which are printed by the package-level log functions
+type Recorder struct {
+	t      testing.TB
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+// Capture records the messages of the package-level log functions for the duration of the test
+//
+// It replaces log.Stdout and log.Stderr, uses Now as the absolute time of the messages, disables the colors and
+// switches the output to JSON so that the messages can be parsed. The previous settings are restored when the test
+// finishes. Don't combine it with log.SetFormatter or sinks as they take precedence over the output format.
+func Capture(t testing.TB) *Recorder {
+
+	t.Helper()
+
+	r := &Recorder{t: t}
+
+	stdout, stderr := log.Stdout, log.Stderr
+	printColors, timeFormat, outputFormat, clock := log.PrintColors, log.TimeFormat, log.OutputFormat, log.Clock
+	timestampMode := log.TimestampMode
+	t.Cleanup(func() {
+		log.Stdout, log.Stderr = stdout, stderr
+		log.PrintColors, log.TimeFormat, log.OutputFormat, log.Clock = printColors, timeFormat, outputFormat, clock
+		log.TimestampMode = timestampMode
+	})
+
+	log.Stdout = r
+	log.Stderr = r
+	log.PrintColors = false
+	log.TimeFormat = time.RFC3339Nano
+	log.OutputFormat = log.FormatJSON
+	log.TimestampMode = log.TimeAbsolute
+	log.Clock = func() time.Time {
+		return Now
+	}
+
+	return r
+
+}
+
+// Write records the output of a message
+func (r *Recorder) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.buffer.Write(p)
+}
+
+// Entries returns the messages which were recorded so far
+//
+// The test fails if the output can't be parsed.
+func (r *Recorder) Entries() []Entry {
+
+	r.t.Helper()
+
+	r.mutex.Lock()
+	data := append([]byte{}, r.buffer.Bytes()...)
+	r.mutex.Unlock()
+
+	var entries []Entry
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	for {
+		var values map[string]interface{}
+		if err := decoder.Decode(&values); err == io.EOF {
+			break
+		} else if err != nil {
+			r.t.Fatalf("logtest: failed to parse the log output: %s", err.Error())
+			return nil
+		}
+		entry, err := parseEntry(values)
+		if err != nil {
+			r.t.Fatalf("logtest: failed to parse the log output: %s", err.Error())
+			return nil
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+
+}
+
+// Messages returns the text of the messages which were recorded so far
+func (r *Recorder) Messages() []string {
+	r.t.Helper()
+	var messages []string
+	for _, entry := range r.Entries() {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+// Reset removes the messages which were recorded so far
+func (r *Recorder) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.buffer.Reset()
+}
+
+func parseEntry(values map[string]interface{}) (Entry, error) {
+
+	var entry Entry
+
+	if tstamp, ok := values["time"].(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, tstamp)
+		if err != nil {
+			return entry, err
+		}
+		entry.Time = t.UTC()
+	}
+
+	levelName, _ := values["level"].(string)
+	if err := entry.Level.UnmarshalText([]byte(levelName)); err != nil {
+		return entry, err
+	}
+	entry.Message, _ = values["msg"].(string)
+
+	entry.Fields = map[string]interface{}{}
+	for key, value := range values {
+		switch key {
+		case "time", "level", "caller", "func", "msg":
+			continue
+		}
+		entry.Fields[key] = fieldValue(value)
+	}
+
+	return entry, nil
+
+}
+
+// fieldValue converts JSON numbers to an int when possible and to a float64 otherwise
+func fieldValue(value interface{}) interface{} {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := number.Int64(); err == nil {
+		return int(i)
+	}
+	f, _ := number.Float64()
+	return f
+}
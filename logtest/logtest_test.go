@@ -0,0 +1,100 @@
+package logtest_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/pieterclaerhout/go-log/logtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapture(t *testing.T) {
+
+	var recorder *logtest.Recorder
+
+	t.Run("capture", func(t *testing.T) {
+
+		recorder = logtest.Capture(t)
+
+		log.Info("hello")
+		log.With("user_id", 42, "ratio", 0.5).Warn("login")
+		log.With(log.Err(errors.New("boom"))).Error("failed")
+		log.InfoDump(map[string]string{"a": "b"}, "dump")
+
+		assert.Equal(t, []logtest.Entry{
+			{Time: logtest.Now, Level: log.InfoLevel, Message: "hello", Fields: map[string]interface{}{}},
+			{Time: logtest.Now, Level: log.WarnLevel, Message: "login", Fields: map[string]interface{}{"user_id": 42, "ratio": 0.5}},
+			{Time: logtest.Now, Level: log.ErrorLevel, Message: "failed", Fields: map[string]interface{}{"error": "boom"}},
+			{Time: logtest.Now, Level: log.InfoLevel, Message: "dump map[string]string{\n  \"a\": \"b\",\n}", Fields: map[string]interface{}{}},
+		}, recorder.Entries())
+
+		recorder.Reset()
+		assert.Empty(t, recorder.Entries())
+
+	})
+
+	assert.NotEqual(t, recorder, log.Stdout)
+	assert.NotEqual(t, recorder, log.Stderr)
+	assert.Equal(t, log.FormatText, log.OutputFormat)
+
+}
+
+func TestCaptureMessages(t *testing.T) {
+
+	recorder := logtest.Capture(t)
+
+	log.Info("one")
+	log.Warn("two")
+
+	assert.Equal(t, []string{"one", "two"}, recorder.Messages())
+
+}
+
+func TestCaptureRelativeTimestamps(t *testing.T) {
+
+	for _, mode := range []log.TimeMode{log.TimeElapsed, log.TimeDelta} {
+
+		log.TimestampMode = mode
+
+		t.Run("capture", func(t *testing.T) {
+			recorder := logtest.Capture(t)
+			log.Info("one")
+			log.Info("two")
+			assert.Equal(t, []logtest.Entry{
+				{Time: logtest.Now, Level: log.InfoLevel, Message: "one", Fields: map[string]interface{}{}},
+				{Time: logtest.Now, Level: log.InfoLevel, Message: "two", Fields: map[string]interface{}{}},
+			}, recorder.Entries())
+		})
+
+		assert.Equal(t, mode, log.TimestampMode)
+		log.TimestampMode = log.TimeAbsolute
+
+	}
+
+}
+
+func TestCaptureRestores(t *testing.T) {
+
+	stdout, printColors, timeFormat := log.Stdout, log.PrintColors, log.TimeFormat
+	defer func() {
+		log.Stdout, log.PrintColors, log.TimeFormat = stdout, printColors, timeFormat
+	}()
+
+	var buf bytes.Buffer
+	log.Stdout = &buf
+	log.PrintColors = true
+	log.TimeFormat = log.DefaultTimeFormat
+
+	t.Run("capture", func(t *testing.T) {
+		logtest.Capture(t)
+		log.Info("captured")
+	})
+
+	assert.Equal(t, &buf, log.Stdout)
+	assert.True(t, log.PrintColors)
+	assert.Equal(t, log.DefaultTimeFormat, log.TimeFormat)
+	assert.Empty(t, buf.String())
+
+}
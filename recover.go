@@ -0,0 +1,109 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+)
+
+// loggedPanic is the value Panic panics with, it marks that the message was already logged
+type loggedPanic struct {
+	message string
+}
+
+// Error returns the message of the panic
+func (p *loggedPanic) Error() string {
+	return p.message
+}
+
+// Recover recovers from a panic and logs it as a panic message with its stack trace
+//
+// If the panic was raised by Panic, the message was already logged and only the stack trace is added. It needs to be
+// deferred directly:
+//
+//	defer log.Recover()
+func Recover() {
+	if value := recover(); value != nil {
+		defaultLogger().logPanic(value)
+	}
+}
+
+// Go runs fn in a new goroutine which logs a panic instead of crashing the program
+func Go(fn func()) {
+	defaultLogger().Go(fn)
+}
+
+// Recover recovers from a panic and logs it as a panic message with its stack trace
+//
+// If the panic was raised by Panic, the message was already logged and only the stack trace is added. It needs to be
+// deferred directly:
+//
+//	defer logger.Recover()
+func (l *Logger) Recover() {
+	if value := recover(); value != nil {
+		l.logPanic(value)
+	}
+}
+
+// Go runs fn in a new goroutine which logs a panic instead of crashing the program
+func (l *Logger) Go(fn func()) {
+	go func() {
+		defer l.Recover()
+		fn()
+	}()
+}
+
+func (l *Logger) logPanic(value interface{}) {
+
+	if p, ok := value.(*loggedPanic); ok {
+		l.logPanicStack(p)
+		return
+	}
+
+	err, ok := value.(error)
+	if !ok {
+		err = fmt.Errorf("%v", value)
+	}
+
+	message := "panic: " + err.Error()
+	if !l.Enabled(PanicLevel) || !l.sample(PanicLevel, message) {
+		return
+	}
+
+	stackTrace := FormattedStackTrace(fmt.Errorf("panic: %w", err))
+	if l.SlogBackend != nil {
+		l.logToSlog(PanicLevel, message, Any("panic", value), String("stacktrace", stackTrace))
+		return
+	}
+
+	entry := l.newEntry(PanicLevel, formatMessage(stackTrace))
+	entry.Error = err
+	entry.Panic = value
+	entry.Stack = stackTrace
+	l.printEntry(entry)
+
+}
+
+// logPanicStack logs the stack trace of a panic raised by Panic without repeating its message
+func (l *Logger) logPanicStack(p *loggedPanic) {
+
+	if !l.Enabled(PanicLevel) {
+		return
+	}
+
+	stackTrace := FormattedStackTrace(fmt.Errorf("panic: %w", p))
+	frames := stackTrace
+	if idx := strings.Index(frames, "\n"); idx >= 0 {
+		frames = frames[idx+1:]
+	}
+
+	if l.SlogBackend != nil {
+		l.logToSlog(PanicLevel, frames, Any("panic", p.message), String("stacktrace", stackTrace))
+		return
+	}
+
+	entry := l.newEntry(PanicLevel, frames)
+	entry.Panic = p.message
+	entry.Stack = stackTrace
+	l.printEntry(entry)
+
+}
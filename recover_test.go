@@ -0,0 +1,162 @@
+package log_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// channelSink sends each entry to a channel
+type channelSink chan *log.Entry
+
+func (s channelSink) Enabled(level log.Level) bool {
+	return true
+}
+
+func (s channelSink) Write(entry *log.Entry) error {
+	s <- entry
+	return nil
+}
+
+func TestLoggerPanic(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+
+	assert.PanicsWithError(t, "panic 1", func() {
+		logger.Panicf("panic %d", 1)
+	})
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "test | PANIC | panic 1\n", stderr.String())
+
+}
+
+func TestPanic(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+
+	assert.PanicsWithError(t, "boom", func() {
+		log.Panic("boom")
+	})
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "test | PANIC | boom\n", stderr.String())
+
+}
+
+func TestLoggerRecover(t *testing.T) {
+
+	sink := make(channelSink, 1)
+
+	logger, _, _ := newTestLogger()
+	logger.Sinks = []log.Sink{sink}
+
+	err := errors.New("boom")
+
+	assert.NotPanics(t, func() {
+		defer logger.Recover()
+		panic(err)
+	})
+
+	entry := <-sink
+	assert.Equal(t, log.PanicLevel, entry.Level)
+	assert.Equal(t, err, entry.Panic)
+	assert.Equal(t, err, entry.Error)
+	assert.True(t, strings.HasPrefix(entry.Message, "panic: boom\n"))
+	assert.True(t, strings.HasPrefix(entry.Stack, "panic: boom\n"))
+	assert.Contains(t, entry.Stack, "TestLoggerRecover")
+
+}
+
+func TestRecover(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+	log.PrintCaller = true
+
+	assert.NotPanics(t, func() {
+		defer log.Recover()
+		panic("boom")
+	})
+
+	assert.Equal(t, "", stdout.String())
+	assert.True(t, strings.HasPrefix(stderr.String(), "test | PANIC | "+currentFile()+":"), stderr.String())
+	assert.Contains(t, stderr.String(), " | panic: boom\n")
+
+}
+
+func TestRecoverWithoutPanic(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+
+	func() {
+		defer logger.Recover()
+	}()
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "", stderr.String())
+
+}
+
+func TestLoggerGo(t *testing.T) {
+
+	sink := make(channelSink, 1)
+
+	logger, _, _ := newTestLogger()
+	logger.Sinks = []log.Sink{sink}
+
+	logger.Go(func() {
+		panic("boom")
+	})
+
+	select {
+	case entry := <-sink:
+		assert.Equal(t, log.PanicLevel, entry.Level)
+		assert.Equal(t, "boom", entry.Panic)
+		assert.True(t, strings.HasPrefix(entry.Stack, "panic: boom\n"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("panic was not logged")
+	}
+
+}
+
+func TestLoggerGoPanic(t *testing.T) {
+
+	sink := make(channelSink, 2)
+
+	logger, _, _ := newTestLogger()
+	logger.Sinks = []log.Sink{sink}
+
+	logger.Go(func() {
+		logger.Panic("boom")
+	})
+
+	entry := <-sink
+	assert.Equal(t, log.PanicLevel, entry.Level)
+	assert.Equal(t, "boom", entry.Message)
+	assert.Empty(t, entry.Stack)
+
+	select {
+	case entry := <-sink:
+		assert.Equal(t, log.PanicLevel, entry.Level)
+		assert.Equal(t, "boom", entry.Panic)
+		assert.Nil(t, entry.Error)
+		assert.NotContains(t, entry.Message, "boom")
+		assert.True(t, strings.HasPrefix(entry.Stack, "panic: boom\n"))
+		assert.Contains(t, entry.Stack, "TestLoggerGoPanic")
+	case <-time.After(5 * time.Second):
+		t.Fatal("stack trace was not logged")
+	}
+
+}
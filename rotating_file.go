@@ -0,0 +1,337 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the format of the timestamp which is added to the name of rotated files
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile is an io.Writer which writes to a file and rotates it when it gets too large or too old
+//
+// It can be assigned to Stdout or Stderr. The file is opened on the first write. Rotated files get a timestamp in
+// their name and are optionally compressed and pruned in the background. It is safe for concurrent use.
+type RotatingFile struct {
+
+	// Filename is the path of the file to write to
+	Filename string
+
+	// MaxSize is the maximum size in bytes of the file before it gets rotated (0 means no limit)
+	MaxSize int64
+
+	// RotateEvery is the interval after which the file is rotated, regardless of its size (0 means never)
+	RotateEvery time.Duration
+
+	// MaxBackups is the maximum number of rotated files to keep (0 means keep all of them)
+	MaxBackups int
+
+	// MaxAge is the maximum age of the rotated files to keep (0 means keep all of them)
+	MaxAge time.Duration
+
+	// Compress indicates if the rotated files should be compressed using gzip
+	Compress bool
+
+	// Clock returns the current time (defaults to time.Now)
+	Clock func() time.Time
+
+	mutex        sync.Mutex
+	file         *os.File
+	size         int64
+	nextRotation time.Time
+
+	cleanupMutex sync.Mutex
+	cleanupWg    sync.WaitGroup
+}
+
+// NewRotatingFile returns a rotating file which rotates filename when it's larger than maxSize bytes
+func NewRotatingFile(filename string, maxSize int64) *RotatingFile {
+	return &RotatingFile{
+		Filename: filename,
+		MaxSize:  maxSize,
+	}
+}
+
+// Write writes p to the file, rotating it first if needed
+func (f *RotatingFile) Write(p []byte) (int, error) {
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.shouldRotate(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+
+}
+
+// Rotate closes the current file, renames it and opens a new one
+func (f *RotatingFile) Rotate() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	return f.rotate()
+}
+
+// Reopen closes the file so that it is opened again on the next write
+//
+// Use this when the file was moved or removed by an external tool such as logrotate.
+func (f *RotatingFile) Reopen() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.close()
+}
+
+// Close closes the file and waits for the background compression and pruning to finish
+func (f *RotatingFile) Close() error {
+	f.mutex.Lock()
+	err := f.close()
+	f.mutex.Unlock()
+	f.cleanupWg.Wait()
+	return err
+}
+
+func (f *RotatingFile) now() time.Time {
+	if f.Clock != nil {
+		return f.Clock()
+	}
+	return time.Now()
+}
+
+func (f *RotatingFile) open() error {
+
+	if err := os.MkdirAll(filepath.Dir(f.Filename), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.nextRotation = time.Time{}
+	if f.RotateEvery > 0 {
+		f.nextRotation = f.now().Truncate(f.RotateEvery).Add(f.RotateEvery)
+	}
+
+	return nil
+
+}
+
+func (f *RotatingFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *RotatingFile) shouldRotate(length int64) bool {
+	if f.MaxSize > 0 && f.size > 0 && f.size+length > f.MaxSize {
+		return true
+	}
+	if !f.nextRotation.IsZero() && !f.now().Before(f.nextRotation) {
+		if f.size == 0 {
+			f.nextRotation = f.now().Truncate(f.RotateEvery).Add(f.RotateEvery)
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+func (f *RotatingFile) rotate() error {
+
+	if err := f.close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(f.Filename, f.backupName()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.cleanupWg.Add(1)
+	go f.cleanup()
+
+	return nil
+
+}
+
+func (f *RotatingFile) backupName() string {
+	prefix, ext := f.backupPrefixAndExt()
+	timestamp := f.now().UTC().Format(backupTimeFormat)
+	name := prefix + timestamp + ext
+	for i := 1; fileExists(name) || fileExists(name+".gz"); i++ {
+		name = fmt.Sprintf("%s%s.%d%s", prefix, timestamp, i, ext)
+	}
+	return name
+}
+
+func (f *RotatingFile) backupPrefixAndExt() (string, string) {
+	ext := filepath.Ext(f.Filename)
+	return strings.TrimSuffix(f.Filename, ext) + "-", ext
+}
+
+func (f *RotatingFile) cleanup() {
+
+	defer f.cleanupWg.Done()
+
+	f.cleanupMutex.Lock()
+	defer f.cleanupMutex.Unlock()
+
+	backups, err := f.backups()
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Time{}
+	if f.MaxAge > 0 {
+		cutoff = f.now().Add(-f.MaxAge)
+	}
+
+	for idx, backup := range backups {
+		if (f.MaxBackups > 0 && idx >= f.MaxBackups) || backup.timestamp.Before(cutoff) {
+			os.Remove(backup.path)
+			continue
+		}
+		if f.Compress && !strings.HasSuffix(backup.path, ".gz") {
+			compressFile(backup.path)
+		}
+	}
+
+}
+
+type rotatingFileBackup struct {
+	path      string
+	timestamp time.Time
+	counter   int
+}
+
+// backups returns the rotated files, newest first
+func (f *RotatingFile) backups() ([]rotatingFileBackup, error) {
+
+	prefix, ext := f.backupPrefixAndExt()
+
+	entries, err := os.ReadDir(filepath.Dir(f.Filename))
+	if err != nil {
+		return nil, err
+	}
+
+	// compare the base names, filepath.Join cleans the path and would strip a leading "./" from the prefix
+	prefix = filepath.Base(prefix)
+
+	var backups []rotatingFileBackup
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		path := filepath.Join(filepath.Dir(f.Filename), entry.Name())
+		name := strings.TrimPrefix(entry.Name(), prefix)
+		name = strings.TrimSuffix(name, ".gz")
+		if !strings.HasSuffix(name, ext) {
+			continue
+		}
+		name = strings.TrimSuffix(name, ext)
+		if len(name) < len(backupTimeFormat) {
+			continue
+		}
+		timestamp, err := time.ParseInLocation(backupTimeFormat, name[:len(backupTimeFormat)], time.UTC)
+		if err != nil {
+			continue
+		}
+		counter := 0
+		if suffix := name[len(backupTimeFormat):]; suffix != "" {
+			if counter, err = strconv.Atoi(strings.TrimPrefix(suffix, ".")); err != nil {
+				continue
+			}
+		}
+		backups = append(backups, rotatingFileBackup{path: path, timestamp: timestamp, counter: counter})
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		if backups[i].timestamp.Equal(backups[j].timestamp) {
+			return backups[i].counter > backups[j].counter
+		}
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+
+	return backups, nil
+
+}
+
+func compressFile(path string) error {
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+
+	src.Close()
+	return os.Remove(path)
+
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
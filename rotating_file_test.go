@@ -0,0 +1,257 @@
+package log_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFileMaxSize(t *testing.T) {
+
+	dir := t.TempDir()
+	clock := newTestClock()
+
+	f := log.NewRotatingFile(filepath.Join(dir, "app.log"), 11)
+	f.Clock = clock.Now
+
+	write(t, f, "12345\n")
+	write(t, f, "6789\n")
+	clock.Advance(time.Second)
+	write(t, f, "abcde\n")
+
+	assert.NoError(t, f.Close())
+
+	files := listFiles(t, dir)
+	assert.Equal(t, []string{"app-2020-01-02T03-04-06.000.log", "app.log"}, files)
+	assert.Equal(t, "12345\n6789\n", readFile(t, filepath.Join(dir, files[0])))
+	assert.Equal(t, "abcde\n", readFile(t, filepath.Join(dir, "app.log")))
+
+}
+
+func TestRotatingFileRotateEvery(t *testing.T) {
+
+	dir := t.TempDir()
+	clock := newTestClock()
+
+	f := &log.RotatingFile{
+		Filename:    filepath.Join(dir, "app.log"),
+		RotateEvery: time.Hour,
+		Clock:       clock.Now,
+	}
+
+	write(t, f, "first\n")
+	clock.Advance(30 * time.Minute)
+	write(t, f, "second\n")
+	clock.Advance(30 * time.Minute)
+	write(t, f, "third\n")
+
+	assert.NoError(t, f.Close())
+
+	files := listFiles(t, dir)
+	assert.Equal(t, []string{"app-2020-01-02T04-04-05.000.log", "app.log"}, files)
+	assert.Equal(t, "first\nsecond\n", readFile(t, filepath.Join(dir, files[0])))
+	assert.Equal(t, "third\n", readFile(t, filepath.Join(dir, "app.log")))
+
+}
+
+func TestRotatingFileCompressAndMaxBackups(t *testing.T) {
+
+	dir := t.TempDir()
+	clock := newTestClock()
+
+	f := &log.RotatingFile{
+		Filename:   filepath.Join(dir, "app.log"),
+		MaxBackups: 2,
+		Compress:   true,
+		Clock:      clock.Now,
+	}
+
+	for _, line := range []string{"1\n", "2\n", "3\n", "4\n"} {
+		write(t, f, line)
+		clock.Advance(time.Second)
+		assert.NoError(t, f.Rotate())
+	}
+
+	assert.NoError(t, f.Close())
+
+	files := listFiles(t, dir)
+	assert.Equal(t, []string{"app-2020-01-02T03-04-08.000.log.gz", "app-2020-01-02T03-04-09.000.log.gz", "app.log"}, files)
+	assert.Equal(t, "3\n", readGzipFile(t, filepath.Join(dir, files[0])))
+	assert.Equal(t, "4\n", readGzipFile(t, filepath.Join(dir, files[1])))
+
+}
+
+func TestRotatingFileRelativePath(t *testing.T) {
+
+	dir := t.TempDir()
+	clock := newTestClock()
+
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+
+	f := log.NewRotatingFile("./logs/app.log", 2)
+	f.MaxBackups = 1
+	f.Compress = true
+	f.Clock = clock.Now
+
+	for _, line := range []string{"1\n", "2\n", "3\n", "4\n", "5\n"} {
+		write(t, f, line)
+		clock.Advance(time.Second)
+	}
+
+	assert.NoError(t, f.Close())
+
+	files := listFiles(t, filepath.Join(dir, "logs"))
+	assert.Equal(t, []string{"app-2020-01-02T03-04-09.000.log.gz", "app.log"}, files)
+	assert.Equal(t, "4\n", readGzipFile(t, filepath.Join(dir, "logs", files[0])))
+	assert.Equal(t, "5\n", readFile(t, filepath.Join(dir, "logs", "app.log")))
+
+}
+
+func TestRotatingFileMaxAge(t *testing.T) {
+
+	dir := t.TempDir()
+	clock := newTestClock()
+
+	f := &log.RotatingFile{
+		Filename: filepath.Join(dir, "app.log"),
+		MaxAge:   time.Hour,
+		Clock:    clock.Now,
+	}
+
+	write(t, f, "old\n")
+	assert.NoError(t, f.Rotate())
+	clock.Advance(2 * time.Hour)
+	write(t, f, "new\n")
+	assert.NoError(t, f.Rotate())
+
+	assert.NoError(t, f.Close())
+
+	files := listFiles(t, dir)
+	assert.Equal(t, []string{"app-2020-01-02T05-04-05.000.log", "app.log"}, files)
+	assert.Equal(t, "new\n", readFile(t, filepath.Join(dir, files[0])))
+
+}
+
+func TestRotatingFileReopen(t *testing.T) {
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	f := log.NewRotatingFile(path, 0)
+
+	write(t, f, "first\n")
+	assert.NoError(t, os.Rename(path, path+".1"))
+	assert.NoError(t, f.Reopen())
+	write(t, f, "second\n")
+
+	assert.NoError(t, f.Close())
+
+	assert.Equal(t, "first\n", readFile(t, path+".1"))
+	assert.Equal(t, "second\n", readFile(t, path))
+
+}
+
+func TestRotatingFileConcurrentWrites(t *testing.T) {
+
+	dir := t.TempDir()
+
+	f := log.NewRotatingFile(filepath.Join(dir, "app.log"), 100)
+
+	logger := log.New()
+	logger.PrintTimestamp = false
+	logger.PrintColors = false
+	logger.Stdout = f
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				logger.Info("0123456789")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.NoError(t, f.Close())
+
+	total := 0
+	for _, name := range listFiles(t, dir) {
+		content := readFile(t, filepath.Join(dir, name))
+		assert.True(t, len(content) <= 100, name)
+		total += strings.Count(content, "0123456789\n")
+	}
+	assert.Equal(t, 500, total)
+
+}
+
+type testClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func write(t *testing.T, w io.Writer, data string) {
+	t.Helper()
+	_, err := w.Write([]byte(data))
+	assert.NoError(t, err)
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func readGzipFile(t *testing.T, path string) string {
+	t.Helper()
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	assert.NoError(t, err)
+	data, err := io.ReadAll(gz)
+	assert.NoError(t, err)
+	return string(data)
+}
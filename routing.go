@@ -0,0 +1,130 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Stream is one of the standard output streams of a logger
+//
+// When used in a RoutingTable, it refers to the Stdout or Stderr of the logger at the time of writing.
+type Stream int
+
+const (
+	// StreamStdout is the stream written to Stdout
+	StreamStdout Stream = iota
+
+	// StreamStderr is the stream written to Stderr
+	StreamStderr
+)
+
+// Write writes p to os.Stdout or os.Stderr
+//
+// A logger doesn't call it, it writes to its own Stdout or Stderr instead.
+func (s Stream) Write(p []byte) (int, error) {
+	if s == StreamStderr {
+		return os.Stderr.Write(p)
+	}
+	return os.Stdout.Write(p)
+}
+
+// RoutingTable maps each level to the writer to which its messages are written
+//
+// Use StreamStdout and StreamStderr to write to the Stdout and Stderr of the logger. Levels which are not in the table
+// use the default routing, which writes errors and fatal errors to Stderr and everything else to Stdout.
+type RoutingTable map[Level]io.Writer
+
+// SplitRouting returns a routing table which writes the messages with at least level to Stderr and all others to
+// Stdout
+func SplitRouting(level Level) RoutingTable {
+	table := RoutingTable{}
+	for lvl := range levelNames {
+		if lvl >= level {
+			table[lvl] = StreamStderr
+		} else {
+			table[lvl] = StreamStdout
+		}
+	}
+	return table
+}
+
+// DefaultRouting writes errors and fatal errors to Stderr and everything else to Stdout
+var DefaultRouting = SplitRouting(ErrorLevel)
+
+// AllStderrRouting writes all messages to Stderr, keeping Stdout clean for piped data
+var AllStderrRouting = SplitRouting(TraceLevel)
+
+// AllStdoutRouting writes all messages to Stdout
+var AllStdoutRouting = SplitRouting(FatalLevel + 1)
+
+// SplitAtWarnRouting writes warnings, errors and fatal errors to Stderr and everything else to Stdout
+var SplitAtWarnRouting = SplitRouting(WarnLevel)
+
+// ParseRouting returns the routing preset with the given name
+//
+// Valid names are "default", "all-stderr", "all-stdout" and "split-at-<level>" such as "split-at-warn".
+func ParseRouting(name string) (RoutingTable, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "default":
+		return DefaultRouting, nil
+	case "all-stderr":
+		return AllStderrRouting, nil
+	case "all-stdout":
+		return AllStdoutRouting, nil
+	}
+	if strings.HasPrefix(name, "split-at-") {
+		level, err := ParseLevel(strings.TrimPrefix(name, "split-at-"))
+		if err != nil {
+			return nil, err
+		}
+		return SplitRouting(level), nil
+	}
+	return nil, fmt.Errorf("unknown log routing: %q", name)
+}
+
+func (l *Logger) writerForLevel(level Level) io.Writer {
+	w, ok := l.Routing[level]
+	if !ok || w == nil {
+		w = StreamStdout
+		if level >= ErrorLevel {
+			w = StreamStderr
+		}
+	}
+	switch w {
+	case StreamStdout:
+		if l.Stdout == nil {
+			return os.Stdout
+		}
+		return l.Stdout
+	case StreamStderr:
+		if l.Stderr == nil {
+			return os.Stderr
+		}
+		return l.Stderr
+	}
+	return w
+}
+
+// routedWriters returns the writers of the routing table other than Stdout and Stderr
+func (l *Logger) routedWriters() []io.Writer {
+	var writers []io.Writer
+	for level := TraceLevel; level <= FatalLevel; level++ {
+		w := l.Routing[level]
+		if _, ok := w.(Stream); w != nil && !ok {
+			writers = append(writers, w)
+		}
+	}
+	return writers
+}
+
+func routingFromEnv() RoutingTable {
+	if name := os.Getenv("LOG_ROUTING"); name != "" {
+		if routing, err := ParseRouting(name); err == nil {
+			return routing
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package log_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouting(t *testing.T) {
+
+	type test struct {
+		name           string
+		routing        log.RoutingTable
+		expectedStdout string
+		expectedStderr string
+	}
+
+	var tests = []test{
+		{"nil", nil, "debug\ninfo\nwarn\n", "error\n"},
+		{"default", log.DefaultRouting, "debug\ninfo\nwarn\n", "error\n"},
+		{"all-stderr", log.AllStderrRouting, "", "debug\ninfo\nwarn\nerror\n"},
+		{"all-stdout", log.AllStdoutRouting, "debug\ninfo\nwarn\nerror\n", ""},
+		{"split-at-warn", log.SplitAtWarnRouting, "debug\ninfo\n", "warn\nerror\n"},
+		{"partial", log.RoutingTable{log.InfoLevel: log.StreamStderr}, "debug\nwarn\n", "info\nerror\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			resetLogConfig()
+			stdout, stderr := redirectOutput()
+			defer resetLogOutput()
+
+			log.PrintColors = false
+			log.PrintTimestamp = false
+			log.DebugMode = true
+			log.Routing = tc.routing
+
+			log.Debug("debug")
+			log.Info("info")
+			log.Warn("warn")
+			log.Error("error")
+
+			assert.Equal(t, tc.expectedStdout, stdout.String(), "stdout")
+			assert.Equal(t, tc.expectedStderr, stderr.String(), "stderr")
+
+		})
+	}
+
+}
+
+func TestParseRouting(t *testing.T) {
+
+	type test struct {
+		name        string
+		input       string
+		expected    log.RoutingTable
+		expectError bool
+	}
+
+	var tests = []test{
+		{"default", "default", log.DefaultRouting, false},
+		{"all-stderr", "ALL-STDERR", log.AllStderrRouting, false},
+		{"all-stdout", "all-stdout", log.AllStdoutRouting, false},
+		{"split-at-warn", "split-at-warn", log.SplitAtWarnRouting, false},
+		{"split-at-info", "split-at-info", log.SplitRouting(log.InfoLevel), false},
+		{"split-at-invalid", "split-at-verbose", nil, true},
+		{"invalid", "everywhere", nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := log.ParseRouting(tc.input)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+}
+
+func TestLoggerRouting(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+	logger.PrintTimestamp = false
+	logger.Routing = log.AllStderrRouting
+
+	logger.Info("info")
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "info\n", stderr.String())
+
+}
+
+func TestLoggerRoutingWriter(t *testing.T) {
+
+	var audit bytes.Buffer
+
+	logger, stdout, stderr := newTestLogger()
+	logger.PrintTimestamp = false
+	logger.Routing = log.RoutingTable{
+		log.InfoLevel:  &audit,
+		log.ErrorLevel: log.StreamStdout,
+	}
+
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+
+	assert.Equal(t, "info\n", audit.String())
+	assert.Equal(t, "warn\nerror\n", stdout.String())
+	assert.Equal(t, "", stderr.String())
+
+}
+
+func TestLoggerRoutingFlush(t *testing.T) {
+
+	var buf bytes.Buffer
+	aw := log.NewAsyncWriter(&buf, 10, log.BackpressureBlock)
+
+	logger, _, _ := newTestLogger()
+	logger.PrintTimestamp = false
+	logger.Routing = log.RoutingTable{log.WarnLevel: aw}
+
+	logger.Warn("warn")
+
+	assert.NoError(t, logger.Close())
+	assert.Equal(t, "warn\n", buf.String())
+
+}
@@ -0,0 +1,210 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SamplingRule defines how many messages with the same key are logged per interval
+type SamplingRule struct {
+
+	// First is the number of messages with the same key which are logged per interval
+	First int
+
+	// Thereafter defines that after the first messages, only every Thereafter-th message is logged (0 drops them all)
+	Thereafter int
+}
+
+// Sampler limits the number of repeated messages which are logged
+//
+// Messages are grouped by their level and text. Per interval, the first messages of a group are logged, after that
+// only every Thereafter-th one. When the interval is over, a summary line reports how many messages were suppressed per
+// level. The summary is printed by a timer at the end of the interval, with the next message if that comes first, or
+// by Flush and Close. Levels without a rule are never sampled. It is safe for concurrent use.
+type Sampler struct {
+
+	// Interval is the period after which the counters are reset
+	Interval time.Duration
+
+	// Rules defines the sampling rule per level
+	Rules map[Level]SamplingRule
+
+	// Clock returns the current time (defaults to time.Now)
+	Clock func() time.Time
+
+	mutex      sync.Mutex
+	counts     map[samplingKey]int
+	suppressed map[Level]int
+	resetAt    time.Time
+	timer      *time.Timer
+}
+
+type samplingKey struct {
+	level   Level
+	message string
+}
+
+type samplingSummary struct {
+	level Level
+	count int
+}
+
+// NewSampler returns a sampler which, per interval, logs the first messages with the same key and after that only
+// every thereafter-th one
+//
+// The rule applies to all levels except PanicLevel and FatalLevel.
+func NewSampler(interval time.Duration, first int, thereafter int) *Sampler {
+	rule := SamplingRule{First: first, Thereafter: thereafter}
+	return &Sampler{
+		Interval: interval,
+		Rules: map[Level]SamplingRule{
+			TraceLevel: rule,
+			DebugLevel: rule,
+			InfoLevel:  rule,
+			WarnLevel:  rule,
+			ErrorLevel: rule,
+		},
+	}
+}
+
+var sampler *Sampler
+
+// SetSampler sets the sampler which is used by the package-level functions
+//
+// Pass nil to disable sampling.
+func SetSampler(s *Sampler) {
+	sampler = s
+}
+
+// check returns if a message should be logged and, if the previous interval is over, what was suppressed in it
+//
+// When the first message of the interval is suppressed, a timer is started which prints the summary using l at the end
+// of the interval.
+func (s *Sampler) check(l *Logger, level Level, message string) (bool, []samplingSummary) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+
+	var summaries []samplingSummary
+	if s.counts == nil || !now.Before(s.resetAt) {
+		summaries = s.summaries()
+		s.counts = map[samplingKey]int{}
+		s.resetAt = now.Add(s.Interval)
+	}
+
+	rule, ok := s.Rules[level]
+	if !ok {
+		return true, summaries
+	}
+
+	key := samplingKey{level: level, message: message}
+	s.counts[key]++
+
+	count := s.counts[key]
+	if count <= rule.First {
+		return true, summaries
+	}
+	if rule.Thereafter > 0 && (count-rule.First)%rule.Thereafter == 0 {
+		return true, summaries
+	}
+
+	if s.suppressed == nil {
+		s.suppressed = map[Level]int{}
+	}
+	s.suppressed[level]++
+
+	if s.timer == nil {
+		s.timer = time.AfterFunc(s.resetAt.Sub(now), func() {
+			l.printSummaries(s.expire())
+		})
+	}
+
+	return false, summaries
+
+}
+
+// expire ends the interval if it's over and returns what was suppressed in it
+func (s *Sampler) expire() []samplingSummary {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.timer = nil
+	if s.now().Before(s.resetAt) {
+		return nil
+	}
+	s.counts = nil
+	return s.summaries()
+}
+
+// flush returns what was suppressed so far and resets the count
+func (s *Sampler) flush() []samplingSummary {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.summaries()
+}
+
+// summaries returns what was suppressed so far, resets the count and stops the timer
+func (s *Sampler) summaries() []samplingSummary {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	var summaries []samplingSummary
+	for level := TraceLevel; level <= FatalLevel; level++ {
+		if count := s.suppressed[level]; count > 0 {
+			summaries = append(summaries, samplingSummary{level: level, count: count})
+		}
+	}
+	s.suppressed = nil
+	return summaries
+}
+
+func (s *Sampler) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock()
+	}
+	return time.Now()
+}
+
+func (l *Logger) sample(level Level, message string) bool {
+	if l.Sampler == nil {
+		return true
+	}
+	allowed, summaries := l.Sampler.check(l, level, message)
+	l.printSummaries(summaries)
+	return allowed
+}
+
+func (l *Logger) flushSampler() {
+	if l.Sampler == nil {
+		return
+	}
+	l.printSummaries(l.Sampler.flush())
+}
+
+// printSummaries prints the summaries without the fields of l as they cover the messages of all loggers sharing the
+// sampler
+func (l *Logger) printSummaries(summaries []samplingSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+	base := *l
+	base.fields = nil
+	l = &base
+	for _, summary := range summaries {
+		if !l.Enabled(summary.level) {
+			continue
+		}
+		message := fmt.Sprintf("sampling suppressed %d %s messages", summary.count, summary.level.name())
+		field := Int("suppressed", summary.count)
+		if l.SlogBackend != nil {
+			l.logToSlog(summary.level, message, field)
+			continue
+		}
+		entry := l.newEntry(summary.level, message)
+		entry.Fields = append(append([]Field{}, entry.Fields...), field)
+		l.printEntry(entry)
+	}
+}
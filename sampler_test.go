@@ -0,0 +1,140 @@
+package log_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampler(t *testing.T) {
+
+	clock := newTestClock()
+
+	sampler := log.NewSampler(time.Second, 2, 3)
+	sampler.Clock = clock.Now
+
+	logger, stdout, stderr := newTestLogger()
+	logger.Sampler = sampler
+
+	for i := 0; i < 10; i++ {
+		logger.Warnf("disk almost full")
+	}
+	logger.Info("other")
+
+	assert.Equal(t, "test | WARN  | disk almost full\ntest | WARN  | disk almost full\ntest | WARN  | disk almost full\ntest | WARN  | disk almost full\ntest | INFO  | other\n", stdout.String())
+	assert.Empty(t, stderr.String())
+
+	stdout.Reset()
+	clock.Advance(time.Second)
+
+	logger.Warn("disk almost full")
+
+	assert.Equal(t, "test | WARN  | sampling suppressed 6 warn messages suppressed=6\ntest | WARN  | disk almost full\n", stdout.String())
+
+}
+
+func TestSamplerPerLevel(t *testing.T) {
+
+	sampler := &log.Sampler{
+		Interval: time.Minute,
+		Rules: map[log.Level]log.SamplingRule{
+			log.InfoLevel: {First: 1},
+		},
+	}
+
+	logger, stdout, stderr := newTestLogger()
+	logger.Sampler = sampler
+
+	for i := 0; i < 5; i++ {
+		logger.Info("info")
+		logger.Error("error")
+	}
+
+	assert.Equal(t, "test | INFO  | info\n", stdout.String())
+	assert.Equal(t, 5, strings.Count(stderr.String(), "\n"))
+
+	stdout.Reset()
+	assert.NoError(t, logger.Flush())
+	assert.Equal(t, "test | INFO  | sampling suppressed 4 info messages suppressed=4\n", stdout.String())
+
+	stdout.Reset()
+	assert.NoError(t, logger.Flush())
+	assert.Empty(t, stdout.String())
+
+}
+
+func TestSamplerSummaryAtIntervalEnd(t *testing.T) {
+
+	sink := make(channelSink, 10)
+
+	logger, _, _ := newTestLogger()
+	logger.Sinks = []log.Sink{sink}
+	logger.Sampler = log.NewSampler(50*time.Millisecond, 1, 0)
+
+	for i := 0; i < 3; i++ {
+		logger.Warn("disk almost full")
+	}
+
+	assert.Equal(t, "disk almost full", (<-sink).Message)
+
+	select {
+	case entry := <-sink:
+		assert.Equal(t, log.WarnLevel, entry.Level)
+		assert.Equal(t, "sampling suppressed 2 warn messages", entry.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("summary was not printed at the end of the interval")
+	}
+
+	assert.NoError(t, logger.Flush())
+	assert.Empty(t, sink)
+
+}
+
+func TestSamplerSummaryWithoutFields(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.Sampler = log.NewSampler(time.Minute, 1, 0)
+
+	request := logger.With("request_id", 7)
+	request.Warn("hot")
+	request.Warn("hot")
+	logger.Warn("hot")
+
+	assert.NoError(t, request.Flush())
+	assert.Equal(t, "test | WARN  | hot request_id=7\ntest | WARN  | sampling suppressed 2 warn messages suppressed=2\n", stdout.String())
+
+}
+
+func TestSamplerDifferentMessages(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.Sampler = log.NewSampler(time.Minute, 1, 0)
+
+	logger.Info("one")
+	logger.Info("two")
+	logger.Info("one")
+	logger.InfoSeparator("one")
+
+	assert.Equal(t, "test | INFO  | one\ntest | INFO  | two\ntest | INFO  | ====[ one ]=====================================================================\n", stdout.String())
+
+}
+
+func TestSetSampler(t *testing.T) {
+
+	resetLogConfig()
+	stdout, _ := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+	log.SetSampler(log.NewSampler(time.Minute, 1, 0))
+	defer log.SetSampler(nil)
+
+	log.Info("info")
+	log.Info("info")
+
+	assert.Equal(t, "test | INFO  | info\n", stdout.String())
+
+}
@@ -0,0 +1,113 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// Sink is an output to which the log entries are written
+//
+// Besides writing to an output, a sink can also be used as a hook which is called for each log entry.
+type Sink interface {
+
+	// Enabled returns true if the sink wants to receive entries with the given level
+	Enabled(level Level) bool
+
+	// Write writes the entry to the sink
+	Write(entry *Entry) error
+}
+
+// WriterSink is a sink which writes the formatted entries to an io.Writer
+type WriterSink struct {
+
+	// Writer is the writer to which the entries are written
+	Writer io.Writer
+
+	// Formatter renders the entries, if nil the formatter of the logger is used
+	Formatter Formatter
+
+	// MinLevel is the minimum level an entry needs to have to be written
+	MinLevel Level
+
+	mutex sync.Mutex
+}
+
+// NewWriterSink returns a sink which writes the entries with at least minLevel to w using formatter
+//
+// If formatter is nil, the formatter of the logger is used.
+func NewWriterSink(w io.Writer, formatter Formatter, minLevel Level) *WriterSink {
+	return &WriterSink{
+		Writer:    w,
+		Formatter: formatter,
+		MinLevel:  minLevel,
+	}
+}
+
+// Enabled returns true if level is at least the minimum level of the sink
+func (s *WriterSink) Enabled(level Level) bool {
+	return level >= s.MinLevel
+}
+
+// Write formats the entry and writes it to the writer
+func (s *WriterSink) Write(entry *Entry) error {
+
+	formatter := s.Formatter
+	if formatter == nil {
+		formatter = entry.Logger.getFormatter()
+	}
+
+	data, err := formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, err = s.Writer.Write(data)
+	return err
+
+}
+
+// Flush flushes the writer if it supports it
+func (s *WriterSink) Flush() error {
+	return flushWriter(s.Writer)
+}
+
+// Close closes the writer if it supports it, os.Stdout and os.Stderr are never closed
+func (s *WriterSink) Close() error {
+	return closeWriter(s.Writer)
+}
+
+var sinks []Sink
+var sinksMutex sync.RWMutex
+
+// AddSink adds a sink to which the messages of the package-level functions are written
+//
+// As soon as a sink is added, the messages are no longer written to Stdout and Stderr but only to the sinks.
+func AddSink(sink Sink) {
+	sinksMutex.Lock()
+	defer sinksMutex.Unlock()
+	sinks = append(sinks[:len(sinks):len(sinks)], sink)
+}
+
+// ResetSinks removes all sinks so that the messages are written to Stdout and Stderr again
+func ResetSinks() {
+	sinksMutex.Lock()
+	defer sinksMutex.Unlock()
+	sinks = nil
+}
+
+func registeredSinks() []Sink {
+	sinksMutex.RLock()
+	defer sinksMutex.RUnlock()
+	return sinks
+}
+
+func (l *Logger) writeToSinks(entry *Entry) {
+	for _, sink := range l.Sinks {
+		if sink.Enabled(entry.Level) {
+			sink.Write(entry)
+		}
+	}
+}
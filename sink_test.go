@@ -0,0 +1,107 @@
+package log_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingSink struct {
+	entries []*log.Entry
+}
+
+func (s *recordingSink) Enabled(level log.Level) bool {
+	return level >= log.WarnLevel
+}
+
+func (s *recordingSink) Write(entry *log.Entry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func TestSinks(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+	logger.DebugMode = true
+	logger.PrintColors = true
+
+	var terminal, file bytes.Buffer
+	logger.Sinks = []log.Sink{
+		log.NewWriterSink(&terminal, &log.TextFormatter{}, log.DebugLevel),
+		log.NewWriterSink(&file, &log.JSONFormatter{}, log.InfoLevel),
+	}
+
+	logger.Debug("debug")
+	logger.With("user_id", 42).Info("info")
+	logger.Error("error")
+
+	expectedTerminal := "\x1b[90mtest | DEBUG | debug\x1b[0m\n" +
+		"\x1b[92mtest | INFO  | info user_id=42\x1b[0m\n" +
+		"\x1b[91mtest | ERROR | error\x1b[0m\n"
+
+	expectedFile := `{"time":"test","level":"info","msg":"info","user_id":42}` + "\n" +
+		`{"time":"test","level":"error","msg":"error"}` + "\n"
+
+	assert.Equal(t, expectedTerminal, terminal.String())
+	assert.Equal(t, expectedFile, file.String())
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "", stderr.String())
+
+}
+
+func TestWriterSinkDefaultFormatter(t *testing.T) {
+
+	logger, _, _ := newTestLogger()
+	logger.OutputFormat = log.FormatLogfmt
+
+	var buf bytes.Buffer
+	logger.Sinks = []log.Sink{log.NewWriterSink(&buf, nil, log.TraceLevel)}
+
+	logger.Warn("warn")
+
+	assert.Equal(t, "ts=test level=warn msg=warn\n", buf.String())
+
+}
+
+func TestSinkAsHook(t *testing.T) {
+
+	logger, _, _ := newTestLogger()
+
+	var buf bytes.Buffer
+	sink := &recordingSink{}
+	logger.Sinks = []log.Sink{log.NewWriterSink(&buf, nil, log.TraceLevel), sink}
+
+	logger.Info("info")
+	logger.Warn("warn")
+
+	assert.Equal(t, "test | INFO  | info\ntest | WARN  | warn\n", buf.String())
+	assert.Len(t, sink.entries, 1)
+	assert.Equal(t, "warn", sink.entries[0].Message)
+
+}
+
+func TestAddSink(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+	defer log.ResetSinks()
+
+	log.PrintColors = false
+
+	var buf bytes.Buffer
+	log.AddSink(log.NewWriterSink(&buf, nil, log.WarnLevel))
+
+	log.Info("info")
+	log.Warn("warn")
+
+	log.ResetSinks()
+	log.Info("info")
+
+	assert.Equal(t, "test | WARN  | warn\n", buf.String())
+	assert.Equal(t, "test | INFO  | info\n", stdout.String())
+	assert.Equal(t, "", stderr.String())
+
+}
@@ -0,0 +1,66 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLevelPanic is the slog level used for panic messages sent to a slog backend
+const SlogLevelPanic = slog.LevelError + 2
+
+// SlogLevelFatal is the slog level used for fatal messages sent to a slog backend
+const SlogLevelFatal = slog.LevelError + 4
+
+// SlogLevelTrace is the slog level used for trace messages sent to a slog backend
+const SlogLevelTrace = slog.LevelDebug - 4
+
+var slogBackend slog.Handler
+
+// SetSlogBackend sends all messages from the package-level functions to handler instead of printing them
+//
+// The go-log levels are mapped to the matching slog levels. Separators are sent with a "separator" attribute, dumps
+// with a "dump" attribute, stack traces with a "stacktrace" attribute and recovered panics with a "panic" attribute as
+// well. Pass nil to print the messages again.
+func SetSlogBackend(handler slog.Handler) {
+	slogBackend = handler
+}
+
+func (l *Logger) logToSlog(level Level, message string, extra ...Field) {
+
+	ctx := context.Background()
+
+	slogLevel := level.slogLevel()
+	if !l.SlogBackend.Enabled(ctx, slogLevel) {
+		return
+	}
+
+	record := slog.NewRecord(l.now(), slogLevel, message, l.callerPC())
+	for _, field := range l.fields {
+		record.AddAttrs(slog.Any(field.Key, field.Value))
+	}
+	for _, field := range extra {
+		record.AddAttrs(slog.Any(field.Key, field.Value))
+	}
+
+	l.SlogBackend.Handle(ctx, record)
+
+}
+
+func (lvl Level) slogLevel() slog.Level {
+	switch lvl {
+	case TraceLevel:
+		return SlogLevelTrace
+	case DebugLevel:
+		return slog.LevelDebug
+	case InfoLevel:
+		return slog.LevelInfo
+	case WarnLevel:
+		return slog.LevelWarn
+	case ErrorLevel:
+		return slog.LevelError
+	case PanicLevel:
+		return SlogLevelPanic
+	default:
+		return SlogLevelFatal
+	}
+}
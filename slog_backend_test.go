@@ -0,0 +1,129 @@
+package log_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSlogBackend(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+	defer log.SetSlogBackend(nil)
+
+	var buf bytes.Buffer
+	log.SetSlogBackend(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: log.SlogLevelTrace}))
+
+	log.DebugMode = true
+
+	log.Debug("debug")
+	log.With("user_id", 42).Infof("info %d", 1)
+	log.WarnSeparator("title")
+	log.ErrorDump(map[string]string{"hello": "world"}, "prefix")
+	log.StackTrace(errors.New("my error"))
+
+	records := parseSlogRecords(t, &buf)
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "", stderr.String())
+	assert.Len(t, records, 5)
+
+	assert.Equal(t, "DEBUG", records[0]["level"])
+	assert.Equal(t, "debug", records[0]["msg"])
+
+	assert.Equal(t, "INFO", records[1]["level"])
+	assert.Equal(t, "info 1", records[1]["msg"])
+	assert.Equal(t, float64(42), records[1]["user_id"])
+
+	assert.Equal(t, "WARN", records[2]["level"])
+	assert.Equal(t, "title", records[2]["msg"])
+	assert.Equal(t, true, records[2]["separator"])
+
+	assert.Equal(t, "ERROR", records[3]["level"])
+	assert.Equal(t, "prefix", records[3]["msg"])
+	assert.Equal(t, "map[string]string{\n  \"hello\": \"world\",\n}", records[3]["dump"])
+
+	assert.Equal(t, "ERROR", records[4]["level"])
+	assert.Equal(t, "my error", records[4]["msg"])
+	assert.True(t, strings.HasPrefix(records[4]["stacktrace"].(string), "my error\n"))
+
+}
+
+func TestSetSlogBackendLevels(t *testing.T) {
+
+	resetLogConfig()
+	redirectOutput()
+	defer resetLogOutput()
+	defer log.SetSlogBackend(nil)
+
+	oldOsExit := log.OsExit
+	defer func() {
+		log.OsExit = oldOsExit
+	}()
+
+	var got int
+	log.OsExit = func(code int) {
+		got = code
+	}
+
+	var buf bytes.Buffer
+	log.SetSlogBackend(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	log.DebugMode = false
+
+	log.Debug("debug")
+	log.Info("info")
+	log.Warn("warn")
+	log.Fatal("fatal")
+
+	records := parseSlogRecords(t, &buf)
+
+	assert.Len(t, records, 2)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, "ERROR+4", records[1]["level"])
+	assert.Equal(t, 1, got)
+
+}
+
+func TestLoggerSlogBackend(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+
+	var buf bytes.Buffer
+	logger.SlogBackend = slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	logger.Info("info")
+
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "level=INFO msg=info\n", buf.String())
+
+}
+
+func parseSlogRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,130 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler is a slog.Handler which renders the records using the same pipeline as the other log functions
+//
+// This way, code which logs using log/slog produces the same output as code using this package.
+type SlogHandler struct {
+	logger *Logger
+	fields []Field
+	prefix string
+}
+
+// NewSlogHandler returns a slog.Handler which writes the records using logger
+//
+// If logger is nil, the settings from the package-level variables are used.
+func NewSlogHandler(logger *Logger) *SlogHandler {
+	return &SlogHandler{logger: logger}
+}
+
+// Enabled reports whether the handler handles records at the given level
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.getLogger().Enabled(levelFromSlog(level))
+}
+
+// Handle renders the record using the logger
+//
+// Fields stored in ctx using WithContext are added to the record.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+
+	contextFields := FieldsFromContext(ctx)
+
+	fields := make([]Field, 0, len(contextFields)+len(h.fields)+record.NumAttrs())
+	fields = append(fields, contextFields...)
+	fields = append(fields, h.fields...)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.prefix, attr)
+		return true
+	})
+
+	logger := h.getLogger().withFields(fields)
+
+	entry := &Entry{
+		Logger:  logger,
+		Time:    record.Time,
+		Level:   levelFromSlog(record.Level),
+		Message: record.Message,
+		Fields:  logger.fields,
+	}
+	if logger.PrintCaller {
+		entry.Caller = frameFromPC(record.PC)
+	}
+	logger.stamp(entry)
+
+	logger.printEntry(entry)
+
+	return nil
+
+}
+
+// WithAttrs returns a new handler which adds the attributes to each record
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	clone := *h
+	clone.fields = h.fields[:len(h.fields):len(h.fields)]
+	for _, attr := range attrs {
+		clone.fields = appendSlogAttr(clone.fields, h.prefix, attr)
+	}
+	return &clone
+}
+
+// WithGroup returns a new handler which qualifies the keys of the attributes that follow with the group name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.prefix = h.prefix + name + "."
+	return &clone
+}
+
+func (h *SlogHandler) getLogger() *Logger {
+	if h.logger == nil {
+		return defaultLogger()
+	}
+	return h.logger
+}
+
+func appendSlogAttr(fields []Field, prefix string, attr slog.Attr) []Field {
+
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			fields = appendSlogAttr(fields, groupPrefix, groupAttr)
+		}
+		return fields
+	}
+
+	return append(fields, Field{Key: prefix + attr.Key, Value: attr.Value.Any()})
+
+}
+
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelDebug:
+		return TraceLevel
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < slog.LevelWarn:
+		return InfoLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	default:
+		return ErrorLevel
+	}
+}
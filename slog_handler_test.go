@@ -0,0 +1,130 @@
+package log_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogHandler(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+	logger.DebugMode = true
+
+	slogger := slog.New(log.NewSlogHandler(logger))
+
+	slogger.Debug("debug")
+	slogger.Info("info", "user_id", 42)
+	slogger.With("request_id", "abc").WithGroup("http").Warn("warn", "status", 404)
+	slogger.Error("error", slog.Group("db", "table", "users"))
+
+	expectedStdout := "test | DEBUG | debug\n" +
+		"test | INFO  | info user_id=42\n" +
+		"test | WARN  | warn request_id=abc http.status=404\n"
+
+	assert.Equal(t, expectedStdout, stdout.String())
+	assert.Equal(t, "test | ERROR | error db.table=users\n", stderr.String())
+
+}
+
+func TestSlogHandlerMatchesLogger(t *testing.T) {
+
+	logger, stdout, _ := newTestLogger()
+	logger.PrintColors = true
+
+	slog.New(log.NewSlogHandler(logger)).Info("hello", "key", "value")
+	logger.With("key", "value").Info("hello")
+
+	lines := strings.SplitAfter(stdout.String(), "\n")
+	assert.Equal(t, lines[0], lines[1])
+
+}
+
+func TestSlogHandlerEnabled(t *testing.T) {
+
+	logger := log.New()
+	logger.DebugMode = false
+	logger.MinLevel = log.TraceLevel
+
+	handler := log.NewSlogHandler(logger)
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelDebug))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelInfo))
+
+	logger.DebugMode = true
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelDebug))
+
+	logger.MinLevel = log.ErrorLevel
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelWarn))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelError+4))
+
+}
+
+func TestSlogHandlerDefaultLogger(t *testing.T) {
+
+	resetLogConfig()
+	stdout, _ := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+
+	slog.New(log.NewSlogHandler(nil)).Info("info")
+
+	assert.Equal(t, "test | INFO  | info\n", stdout.String())
+
+}
+
+func TestSlogHandlerConformance(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	logger := log.New()
+	logger.DebugMode = true
+	logger.OutputFormat = log.FormatJSON
+	logger.Stdout = &buf
+	logger.Stderr = &buf
+
+	results := func() []map[string]interface{} {
+		var records []map[string]interface{}
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var flat map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &flat); err != nil {
+				t.Fatal(err)
+			}
+			records = append(records, nestFields(flat))
+		}
+		return records
+	}
+
+	if err := slogtest.TestHandler(log.NewSlogHandler(logger), results); err != nil {
+		t.Error(err)
+	}
+
+}
+
+func nestFields(flat map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for key, value := range flat {
+		parts := strings.Split(key, ".")
+		current := result
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				current[part] = next
+			}
+			current = next
+		}
+		current[parts[len(parts)-1]] = value
+	}
+	return result
+}
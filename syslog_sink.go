@@ -0,0 +1,300 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyslogFormat is the message format used by a SyslogSink
+type SyslogFormat int
+
+const (
+	// SyslogRFC5424 formats the messages according to RFC 5424
+	SyslogRFC5424 SyslogFormat = iota
+
+	// SyslogRFC3164 formats the messages according to RFC 3164 (BSD syslog)
+	SyslogRFC3164
+)
+
+// SyslogFacility is the syslog facility of the messages
+type SyslogFacility int
+
+// The syslog facilities as defined in RFC 5424
+const (
+	FacilityKern SyslogFacility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLpr
+	FacilityNews
+	FacilityUucp
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFtp
+	FacilityLocal0 SyslogFacility = iota + 4
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// DefaultSyslogTimeout is the timeout used for connecting and writing when a SyslogSink doesn't define one
+const DefaultSyslogTimeout = 5 * time.Second
+
+// syslogSocketPaths are the paths which are tried when no address is given
+var syslogSocketPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogSink is a sink which sends the entries to a syslog server
+//
+// It supports UDP, TCP (using octet-counting framing) and unix sockets. If the connection fails, it reconnects on the
+// next write. Connecting and writing are limited by DialTimeout and WriteTimeout so that an unreachable or stalled
+// server doesn't block the logging.
+type SyslogSink struct {
+
+	// Network is the network to connect to such as "udp", "tcp", "unixgram" or "unix"
+	//
+	// If both Network and Address are empty, the local syslog socket (/dev/log) is used.
+	Network string
+
+	// Address is the address of the syslog server, such as "localhost:514" or "/dev/log"
+	Address string
+
+	// Format is the message format to use (defaults to SyslogRFC5424)
+	Format SyslogFormat
+
+	// Facility is the facility of the messages (defaults to FacilityUser)
+	Facility SyslogFacility
+
+	// AppName is the name of the application (defaults to the name of the executable)
+	AppName string
+
+	// Hostname is the hostname of the machine (defaults to os.Hostname)
+	Hostname string
+
+	// ProcID is the process ID (defaults to os.Getpid)
+	ProcID string
+
+	// MinLevel is the minimum level an entry needs to have to be sent
+	MinLevel Level
+
+	// Formatter renders the message part, if nil the message followed by the fields is sent
+	Formatter Formatter
+
+	// DialTimeout is the maximum time to wait for a connection (defaults to DefaultSyslogTimeout)
+	DialTimeout time.Duration
+
+	// WriteTimeout is the maximum time a write may take before the connection is dropped (defaults to
+	// DefaultSyslogTimeout)
+	WriteTimeout time.Duration
+
+	mutex       sync.Mutex
+	conn        net.Conn
+	connNetwork string
+}
+
+// NewSyslogSink returns a sink which sends the entries to the syslog server at address using network
+//
+// Use an empty network and address to connect to the local syslog socket.
+func NewSyslogSink(network string, address string) *SyslogSink {
+	hostname, _ := os.Hostname()
+	return &SyslogSink{
+		Network:  network,
+		Address:  address,
+		Facility: FacilityUser,
+		AppName:  filepath.Base(os.Args[0]),
+		Hostname: hostname,
+		ProcID:   strconv.Itoa(os.Getpid()),
+	}
+}
+
+// Enabled returns true if level is at least the minimum level of the sink
+func (s *SyslogSink) Enabled(level Level) bool {
+	return level >= s.MinLevel
+}
+
+// Write sends the entry to the syslog server, reconnecting once if the connection was lost
+func (s *SyslogSink) Write(entry *Entry) error {
+
+	message, err := s.formatMessage(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conn != nil {
+		if err = s.send(message); err == nil {
+			return nil
+		}
+		s.close()
+	}
+
+	if err := s.connect(); err != nil {
+		return err
+	}
+
+	if err = s.send(message); err != nil {
+		s.close()
+	}
+
+	return err
+
+}
+
+// Close closes the connection to the syslog server
+func (s *SyslogSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.close()
+}
+
+func (s *SyslogSink) connect() error {
+
+	if s.Network != "" || s.Address != "" {
+		conn, err := net.DialTimeout(s.Network, s.Address, timeoutOrDefault(s.DialTimeout))
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+		s.connNetwork = s.Network
+		return nil
+	}
+
+	for _, path := range syslogSocketPaths {
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.DialTimeout(network, path, timeoutOrDefault(s.DialTimeout)); err == nil {
+				s.conn = conn
+				s.connNetwork = network
+				return nil
+			}
+		}
+	}
+
+	return errors.New("unable to connect to the local syslog socket")
+
+}
+
+func (s *SyslogSink) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *SyslogSink) send(message string) error {
+	if s.isStream() {
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(timeoutOrDefault(s.WriteTimeout))); err != nil {
+		return err
+	}
+	_, err := s.conn.Write([]byte(message))
+	return err
+}
+
+func timeoutOrDefault(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return DefaultSyslogTimeout
+	}
+	return timeout
+}
+
+func (s *SyslogSink) isStream() bool {
+	switch s.connNetwork {
+	case "tcp", "tcp4", "tcp6", "unix":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *SyslogSink) isLocal() bool {
+	return s.Network == "" || strings.HasPrefix(s.Network, "unix")
+}
+
+func (s *SyslogSink) formatMessage(entry *Entry) (string, error) {
+
+	msg := entry.Message
+	if s.Formatter != nil {
+		data, err := s.Formatter.Format(entry)
+		if err != nil {
+			return "", err
+		}
+		msg = strings.TrimSuffix(string(data), "\n")
+	} else if len(entry.Fields) > 0 {
+		msg = msg + " " + formatFields(entry.Fields)
+	}
+
+	tstamp := entry.Time
+	if tstamp.IsZero() {
+		tstamp = time.Now()
+	}
+
+	priority := int(s.Facility)*8 + syslogSeverity(entry.Level)
+
+	if s.Format == SyslogRFC3164 {
+		header := fmt.Sprintf("<%d>%s ", priority, tstamp.Format(time.Stamp))
+		if !s.isLocal() {
+			header += syslogValue(s.Hostname, 255) + " "
+		}
+		return fmt.Sprintf("%s%s[%s]: %s", header, s.AppName, s.ProcID, msg), nil
+	}
+
+	return fmt.Sprintf(
+		"<%d>1 %s %s %s %s - - %s",
+		priority,
+		tstamp.Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogValue(s.Hostname, 255),
+		syslogValue(s.AppName, 48),
+		syslogValue(s.ProcID, 128),
+		msg,
+	), nil
+
+}
+
+func syslogSeverity(level Level) int {
+	switch level {
+	case TraceLevel, DebugLevel:
+		return 7
+	case InfoLevel:
+		return 6
+	case WarnLevel:
+		return 4
+	case ErrorLevel:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// syslogValue returns value as a valid RFC 5424 header field of at most maxLength characters
+func syslogValue(value string, maxLength int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, value)
+	if value == "" {
+		return "-"
+	}
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+	return value
+}
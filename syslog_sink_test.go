@@ -0,0 +1,246 @@
+package log_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyslogSinkUDP(t *testing.T) {
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	sink := newTestSyslogSink("udp", conn.LocalAddr().String())
+	defer sink.Close()
+
+	entry := newTestEntry(log.WarnLevel, "disk almost full", log.Int("percent", 95))
+	assert.NoError(t, sink.Write(entry))
+
+	assert.Equal(t, "<12>1 2020-01-02T03:04:05.000000Z myhost myapp 123 - - disk almost full percent=95", readPacket(t, conn))
+
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 10)
+	go serveOctetCounted(listener, received)
+
+	sink := newTestSyslogSink("tcp", listener.Addr().String())
+	defer sink.Close()
+
+	assert.NoError(t, sink.Write(newTestEntry(log.InfoLevel, "first")))
+	assert.NoError(t, sink.Write(newTestEntry(log.ErrorLevel, "second\nline")))
+
+	assert.Equal(t, "<14>1 2020-01-02T03:04:05.000000Z myhost myapp 123 - - first", waitFor(t, received))
+	assert.Equal(t, "<11>1 2020-01-02T03:04:05.000000Z myhost myapp 123 - - second\nline", waitFor(t, received))
+
+}
+
+func TestSyslogSinkUnixgram(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "log.sock")
+
+	conn, err := net.ListenPacket("unixgram", path)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	sink := newTestSyslogSink("unixgram", path)
+	sink.Format = log.SyslogRFC3164
+	sink.Facility = log.FacilityLocal0
+	defer sink.Close()
+
+	assert.NoError(t, sink.Write(newTestEntry(log.DebugLevel, "debug")))
+	assert.NoError(t, sink.Write(newTestEntry(log.FatalLevel, "fatal")))
+
+	assert.Equal(t, "<135>Jan  2 03:04:05 myapp[123]: debug", readPacket(t, conn))
+	assert.Equal(t, "<130>Jan  2 03:04:05 myapp[123]: fatal", readPacket(t, conn))
+
+}
+
+func TestSyslogSinkRFC3164Remote(t *testing.T) {
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	sink := newTestSyslogSink("udp", conn.LocalAddr().String())
+	sink.Format = log.SyslogRFC3164
+	sink.Formatter = &log.LogfmtFormatter{}
+	defer sink.Close()
+
+	assert.NoError(t, sink.Write(newTestEntry(log.InfoLevel, "hello")))
+
+	assert.Equal(t, "<14>Jan  2 03:04:05 myhost myapp[123]: ts=2020-01-02T03:04:05Z level=info msg=hello", readPacket(t, conn))
+
+}
+
+func TestSyslogSinkReconnect(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 100)
+	accepted := make(chan net.Conn, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+			go readOctetCounted(conn, received)
+		}
+	}()
+
+	sink := newTestSyslogSink("tcp", listener.Addr().String())
+	defer sink.Close()
+
+	assert.NoError(t, sink.Write(newTestEntry(log.InfoLevel, "before")))
+	first := <-accepted
+	assert.True(t, strings.HasSuffix(waitFor(t, received), "before"))
+	first.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		sink.Write(newTestEntry(log.InfoLevel, "after"))
+		select {
+		case second := <-accepted:
+			defer second.Close()
+			assert.True(t, strings.HasSuffix(waitFor(t, received), "after"))
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("sink did not reconnect")
+		}
+	}
+
+}
+
+func TestSyslogSinkWriteTimeout(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	// accept the connection but never read from it
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	sink := newTestSyslogSink("tcp", listener.Addr().String())
+	sink.WriteTimeout = 100 * time.Millisecond
+	defer sink.Close()
+
+	// the socket buffers fill up after a few messages, from then on the writes have to time out instead of blocking
+	message := strings.Repeat("x", 1024*1024)
+	for i := 0; i < 20; i++ {
+		start := time.Now()
+		sink.Write(newTestEntry(log.InfoLevel, message))
+		assert.Less(t, time.Since(start), 2*time.Second)
+	}
+
+	conn := <-accepted
+	conn.Close()
+
+}
+
+func TestSyslogSinkEnabled(t *testing.T) {
+
+	sink := log.NewSyslogSink("udp", "127.0.0.1:514")
+	sink.MinLevel = log.WarnLevel
+
+	assert.False(t, sink.Enabled(log.InfoLevel))
+	assert.True(t, sink.Enabled(log.WarnLevel))
+
+}
+
+func newTestSyslogSink(network string, address string) *log.SyslogSink {
+	sink := log.NewSyslogSink(network, address)
+	sink.Hostname = "myhost"
+	sink.AppName = "myapp"
+	sink.ProcID = "123"
+	return sink
+}
+
+func newTestEntry(level log.Level, message string, fields ...log.Field) *log.Entry {
+	logger := log.New()
+	logger.TimeZone = time.UTC
+	logger.TimeFormat = time.RFC3339
+	return &log.Entry{
+		Logger:  logger,
+		Time:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   level,
+		Message: message,
+		Fields:  fields,
+	}
+}
+
+func readPacket(t *testing.T, conn net.PacketConn) string {
+	t.Helper()
+	buf := make([]byte, 65536)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	assert.NoError(t, err)
+	return string(buf[:n])
+}
+
+func serveOctetCounted(listener net.Listener, received chan<- string) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go readOctetCounted(conn, received)
+	}
+}
+
+func readOctetCounted(conn net.Conn, received chan<- string) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		lengthStr, err := reader.ReadString(' ')
+		if err != nil {
+			return
+		}
+		length, err := strconv.Atoi(strings.TrimSuffix(lengthStr, " "))
+		if err != nil {
+			return
+		}
+		buf := make([]byte, length)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return
+		}
+		received <- string(buf)
+	}
+}
+
+func waitFor(t *testing.T, received <-chan string) string {
+	t.Helper()
+	select {
+	case message := <-received:
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for message")
+		return ""
+	}
+}
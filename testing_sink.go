@@ -0,0 +1,108 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TestingT is the part of testing.TB which is used by ToTesting
+type TestingT interface {
+	Log(args ...interface{})
+	Fail()
+	FailNow()
+	Cleanup(func())
+}
+
+// TestingSink is a sink which writes the messages to the log of a test
+//
+// The messages are only shown when the test fails or when the tests are run in verbose mode.
+type TestingSink struct {
+
+	// T is the test to which the messages are written
+	T TestingT
+
+	// FailOnError indicates if the test should fail when an error, panic or fatal message is logged
+	FailOnError bool
+}
+
+// NewTestingSink returns a sink which writes the messages to the log of t
+func NewTestingSink(t TestingT) *TestingSink {
+	return &TestingSink{T: t}
+}
+
+// Enabled returns true as the sink receives all messages
+func (s *TestingSink) Enabled(level Level) bool {
+	return true
+}
+
+// Write writes the formatted entry to the log of the test
+func (s *TestingSink) Write(entry *Entry) error {
+
+	data, err := entry.Logger.getFormatter().Format(entry)
+	if err != nil {
+		return err
+	}
+
+	s.T.Log(strings.TrimSuffix(string(data), "\n"))
+
+	if s.FailOnError && entry.Level >= ErrorLevel {
+		s.T.Fail()
+	}
+
+	return nil
+
+}
+
+// exitInterceptor replaces the exit of the package-level functions while ToTesting is active
+var exitInterceptor func(code int)
+
+// exit fails the test and stops its goroutine so that the code after Fatal or CheckError doesn't run
+func (s *TestingSink) exit(code int) {
+	s.T.Log(fmt.Sprintf("exit with code %d was intercepted", code))
+	s.T.FailNow()
+}
+
+// ToTesting sends the messages of the package-level functions to the log of t until the test finishes
+//
+// Colors are disabled and Fatal and CheckError no longer exit the program. Instead, they fail the test and stop the
+// goroutine using FailNow, so they need to be called from the goroutine running the test. The OnExit functions are not
+// called. Set FailOnError on the returned sink to fail the test when an error is logged.
+//
+// As the package-level settings are shared, don't use it in parallel tests, give the code under test its own logger
+// using Logger.ToTesting instead.
+func ToTesting(t TestingT) *TestingSink {
+
+	sink := NewTestingSink(t)
+
+	sinksMutex.Lock()
+	previousSinks := sinks
+	sinks = []Sink{sink}
+	sinksMutex.Unlock()
+
+	printColors, previousInterceptor := PrintColors, exitInterceptor
+	t.Cleanup(func() {
+		sinksMutex.Lock()
+		sinks = previousSinks
+		sinksMutex.Unlock()
+		PrintColors, exitInterceptor = printColors, previousInterceptor
+	})
+
+	PrintColors = false
+	exitInterceptor = sink.exit
+
+	return sink
+
+}
+
+// ToTesting sends the messages of the logger to the log of t
+//
+// Colors are disabled and Fatal and CheckError no longer exit the program. Instead, they fail the test and stop the
+// goroutine using FailNow, so they need to be called from the goroutine running the test. The OnExit functions are not
+// called. Set FailOnError on the returned sink to fail the test when an error is logged.
+func (l *Logger) ToTesting(t TestingT) *TestingSink {
+	sink := NewTestingSink(t)
+	l.Sinks = []Sink{sink}
+	l.PrintColors = false
+	l.exitInterceptor = sink.exit
+	return sink
+}
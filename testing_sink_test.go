@@ -0,0 +1,182 @@
+package log_test
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTestingT records what is written to the test log
+type fakeTestingT struct {
+	mutex    sync.Mutex
+	logs     []string
+	failed   bool
+	cleanups []func()
+}
+
+func (t *fakeTestingT) Log(args ...interface{}) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.logs = append(t.logs, fmt.Sprint(args...))
+}
+
+func (t *fakeTestingT) Fail() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.failed = true
+}
+
+func (t *fakeTestingT) FailNow() {
+	t.Fail()
+	runtime.Goexit()
+}
+
+func (t *fakeTestingT) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *fakeTestingT) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+// run runs fn in a new goroutine, like the testing package runs a test, and returns if fn ran to the end
+func (t *fakeTestingT) run(fn func()) bool {
+	finished := false
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+		finished = true
+	}()
+	<-done
+	return finished
+}
+
+func TestToTesting(t *testing.T) {
+
+	resetLogConfig()
+	stdout, stderr := redirectOutput()
+	defer resetLogOutput()
+
+	fake := &fakeTestingT{}
+	log.ToTesting(fake)
+
+	log.Info("info")
+	log.Error("error")
+	assert.False(t, fake.failed)
+
+	finished := fake.run(func() {
+		log.CheckError(errors.New("failed"))
+		log.Info("after exit")
+	})
+
+	assert.False(t, finished)
+	assert.Equal(t, []string{
+		"test | INFO  | info",
+		"test | ERROR | error",
+		"test | FATAL | failed",
+		"exit with code 1 was intercepted",
+	}, fake.logs)
+	assert.True(t, fake.failed)
+
+	fake.finish()
+
+	log.Info("restored")
+
+	assert.Equal(t, "\x1b[92mtest | INFO  | restored\x1b[0m\n", stdout.String())
+	assert.Equal(t, "", stderr.String())
+	assert.Len(t, fake.logs, 4)
+
+}
+
+func TestToTestingFailOnError(t *testing.T) {
+
+	resetLogConfig()
+	redirectOutput()
+	defer resetLogOutput()
+
+	fake := &fakeTestingT{}
+	defer fake.finish()
+
+	log.ToTesting(fake).FailOnError = true
+
+	log.Warn("warn")
+	assert.False(t, fake.failed)
+
+	log.Error("error")
+	assert.True(t, fake.failed)
+
+}
+
+func TestToTestingSkipsExitHandlers(t *testing.T) {
+
+	resetLogConfig()
+	redirectOutput()
+	defer resetLogOutput()
+
+	fake := &fakeTestingT{}
+	log.ToTesting(fake)
+
+	called := false
+	log.OnExit(func() { called = true })
+
+	fake.run(func() {
+		log.Fatal("fatal")
+	})
+
+	fake.finish()
+	assert.False(t, called)
+
+	logger, _, _ := newTestLogger()
+	logger.ToTesting(fake)
+	fake.run(func() {
+		logger.CheckError(errors.New("failed"))
+	})
+	assert.False(t, called)
+
+	logger = log.New()
+	logger.OsExit = func(code int) {}
+	logger.Stderr = &bytes.Buffer{}
+	logger.Fatal("fatal")
+	assert.True(t, called)
+
+}
+
+func TestLoggerToTesting(t *testing.T) {
+
+	logger, stdout, stderr := newTestLogger()
+	logger.PrintColors = true
+
+	fake := &fakeTestingT{}
+	logger.ToTesting(fake).FailOnError = true
+
+	logger.Info("info")
+	assert.False(t, fake.failed)
+
+	logger.Errorf("error %d", 1)
+
+	assert.Equal(t, []string{"test | INFO  | info", "test | ERROR | error 1"}, fake.logs)
+	assert.True(t, fake.failed)
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "", stderr.String())
+
+}
+
+func TestToTestingRealTest(t *testing.T) {
+
+	resetLogConfig()
+	defer resetLogOutput()
+
+	log.ToTesting(t)
+
+	log.Info("this message is written to the test log")
+
+}
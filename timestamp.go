@@ -0,0 +1,94 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimeMode defines how the timestamps of the messages are printed
+type TimeMode int
+
+const (
+	// TimeAbsolute prints the time of the message using TimeZone and TimeFormat
+	TimeAbsolute TimeMode = iota
+
+	// TimeElapsed prints the time which elapsed since the process started (see StartTime)
+	TimeElapsed
+
+	// TimeDelta prints the time which elapsed since the previous message
+	TimeDelta
+)
+
+// ParseTimeZone returns the time zone with the given name
+//
+// Besides the names from the IANA Time Zone database such as "Europe/Brussels", "UTC" and "Local" are accepted. An
+// empty name returns the local time zone.
+func ParseTimeZone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// timeZoneFromEnv returns the time zone from the LOG_TIMEZONE environment variable
+//
+// If it isn't set or invalid, the local time zone is used, which honours the TZ environment variable.
+func timeZoneFromEnv() *time.Location {
+	if name := os.Getenv("LOG_TIMEZONE"); name != "" {
+		if timeZone, err := ParseTimeZone(name); err == nil {
+			return timeZone
+		}
+	}
+	return time.Local
+}
+
+// processStart is the time the process started
+var processStart = time.Now()
+
+// timeTracker remembers the time of the previous message for the delta timestamps
+type timeTracker struct {
+	mutex    sync.Mutex
+	previous time.Time
+}
+
+var timestamps = &timeTracker{}
+
+// swap stores t as the time of the previous message and returns the one it replaces
+func (tt *timeTracker) swap(t time.Time) time.Time {
+	tt.mutex.Lock()
+	defer tt.mutex.Unlock()
+	previous := tt.previous
+	tt.previous = t
+	return previous
+}
+
+// stamp records the time of the entry when delta timestamps are used
+func (l *Logger) stamp(entry *Entry) {
+	if l.TimestampMode != TimeDelta || l.timestamps == nil {
+		return
+	}
+	entry.previous = l.timestamps.swap(entry.Time)
+}
+
+// startTime returns the time from which the elapsed time is measured
+func (l *Logger) startTime() time.Time {
+	if l.StartTime.IsZero() {
+		return processStart
+	}
+	return l.StartTime
+}
+
+func formatElapsed(d time.Duration) string {
+	return fmt.Sprintf("%9.3fs", d.Seconds())
+}
+
+func formatDelta(d time.Duration) string {
+	return fmt.Sprintf("+%8.3fs", d.Seconds())
+}
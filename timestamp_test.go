@@ -0,0 +1,136 @@
+package log_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pieterclaerhout/go-log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeZone(t *testing.T) {
+
+	brussels, _ := time.LoadLocation("Europe/Brussels")
+
+	type test struct {
+		name        string
+		input       string
+		expected    *time.Location
+		expectError bool
+	}
+
+	var tests = []test{
+		{"empty", "", time.Local, false},
+		{"local", "Local", time.Local, false},
+		{"utc", "UTC", time.UTC, false},
+		{"utc-lowercase", " utc ", time.UTC, false},
+		{"iana", "Europe/Brussels", brussels, false},
+		{"invalid", "Invalid/Zone", nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := log.ParseTimeZone(tc.input)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected.String(), actual.String())
+		})
+	}
+
+}
+
+func TestTimeZoneFromEnv(t *testing.T) {
+
+	t.Setenv("LOG_TIMEZONE", "UTC")
+	assert.Equal(t, time.UTC, log.New().TimeZone)
+
+	t.Setenv("LOG_TIMEZONE", "America/New_York")
+	assert.Equal(t, "America/New_York", log.New().TimeZone.String())
+
+	t.Setenv("LOG_TIMEZONE", "Invalid/Zone")
+	assert.Equal(t, time.Local, log.New().TimeZone)
+
+	t.Setenv("LOG_TIMEZONE", "")
+	assert.Equal(t, time.Local, log.New().TimeZone)
+
+}
+
+func TestTimeElapsed(t *testing.T) {
+
+	clock := newTestClock()
+
+	logger, stdout, _ := newTestLogger()
+	logger.TimestampMode = log.TimeElapsed
+	logger.Clock = clock.Now
+	logger.StartTime = clock.Now()
+
+	clock.Advance(250 * time.Millisecond)
+	logger.Info("first")
+	clock.Advance(1250 * time.Millisecond)
+	logger.Info("second")
+	clock.Advance(time.Second)
+	logger.With("key", "value").Info("third")
+
+	assert.Equal(t, "    0.250s | INFO  | first\n    1.500s | INFO  | second\n    2.500s | INFO  | third key=value\n", stdout.String())
+
+}
+
+func TestTimeElapsedSinceProcessStart(t *testing.T) {
+
+	resetLogConfig()
+	stdout, _ := redirectOutput()
+	defer resetLogOutput()
+
+	log.PrintColors = false
+	log.TimestampMode = log.TimeElapsed
+	defer func() {
+		log.TimestampMode = log.TimeAbsolute
+	}()
+	log.Clock = func() time.Time {
+		return log.StartTime.Add(2 * time.Second)
+	}
+
+	log.Info("info")
+
+	assert.Equal(t, "    2.000s | INFO  | info\n", stdout.String())
+	assert.Equal(t, log.StartTime, log.New().StartTime)
+
+}
+
+func TestTimeDelta(t *testing.T) {
+
+	clock := newTestClock()
+
+	logger, stdout, _ := newTestLogger()
+	logger.TimestampMode = log.TimeDelta
+	logger.Clock = clock.Now
+
+	logger.Info("first")
+	clock.Advance(250 * time.Millisecond)
+	logger.Info("second")
+	clock.Advance(2 * time.Second)
+	logger.With("key", "value").Warn("third")
+
+	assert.Equal(t, "+   0.000s | INFO  | first\n+   0.250s | INFO  | second\n+   2.000s | WARN  | third key=value\n", stdout.String())
+
+}
+
+func TestTimeDeltaJSON(t *testing.T) {
+
+	clock := newTestClock()
+
+	logger, stdout, _ := newTestLogger()
+	logger.OutputFormat = log.FormatJSON
+	logger.TimestampMode = log.TimeDelta
+	logger.Clock = clock.Now
+
+	logger.Info("first")
+	clock.Advance(time.Second)
+	logger.Info("second")
+
+	assert.Equal(t, `{"time":"+   0.000s","level":"info","msg":"first"}`+"\n"+`{"time":"+   1.000s","level":"info","msg":"second"}`+"\n", stdout.String())
+
+}
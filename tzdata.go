@@ -0,0 +1,7 @@
+//go:build log_tzdata
+
+package log
+
+// Embed the time zone database so that LOG_TIMEZONE and TimeZone work on systems without tzdata, such as minimal
+// containers. Enable it by building with -tags log_tzdata.
+import _ "time/tzdata"